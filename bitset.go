@@ -0,0 +1,83 @@
+package pcg
+
+// RandomBitset returns a packed bitset of nbits random bits, least significant bit first,
+// in a slice of ceil(nbits/64) words. The unused trailing bits of the last word are zero.
+// It panics if nbits < 0.
+func (p *PCG64) RandomBitset(nbits int) []uint64 {
+	if nbits < 0 {
+		panic("invalid argument to RandomBitset")
+	}
+
+	words := make([]uint64, (nbits+63)/64)
+	for i := range words {
+		words[i] = p.Uint64()
+	}
+	if rem := nbits % 64; rem != 0 {
+		words[len(words)-1] &= 1<<rem - 1
+	}
+	return words
+}
+
+// RandomSubset returns a uniformly random subset of an n-element set as a bitmask:
+// each of the low n bits is set independently with probability 1/2, and all higher bits are zero.
+// It uses a single Uint64 draw.
+// It panics if n < 0 or n > 64.
+func (p *PCG64) RandomSubset(n int) uint64 {
+	if n < 0 || n > 64 {
+		panic("invalid argument to RandomSubset")
+	}
+	if n == 0 {
+		return 0
+	}
+	return p.Uint64() >> (64 - n)
+}
+
+// CoinFlips returns n fair coin flips. Each Uint64 draw supplies 64 flips,
+// least significant bit first, so it is far cheaper than one draw per flip.
+// It panics if n < 0.
+func (p *PCG64) CoinFlips(n int) []bool {
+	if n < 0 {
+		panic("invalid argument to CoinFlips")
+	}
+
+	res := make([]bool, n)
+	var word uint64
+	for i := range res {
+		if i%64 == 0 {
+			word = p.Uint64()
+		}
+		res[i] = word&1 == 1
+		word >>= 1
+	}
+	return res
+}
+
+// A BitStream hands out random bits one at a time from cached Uint64 draws,
+// so 64 calls to NextBit cost a single draw.
+//
+// The cached word belongs to the BitStream, not to the generator: marshaling the PCG64
+// does not capture partially consumed bits, and a BitStream built on a restored generator
+// starts from a fresh word.
+type BitStream struct {
+	p    *PCG64
+	word uint64
+	left uint
+}
+
+// BitStream returns a new BitStream drawing from p.
+func (p *PCG64) BitStream() *BitStream {
+	return &BitStream{p: p}
+}
+
+// NextBit returns the next random bit as a bool, refilling from a Uint64 draw
+// (least significant bit first) when the cached word is exhausted.
+func (b *BitStream) NextBit() bool {
+	if b.left == 0 {
+		b.word = b.p.Uint64()
+		b.left = 64
+	}
+	bit := b.word&1 == 1
+	b.word >>= 1
+	b.left--
+	return bit
+}
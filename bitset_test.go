@@ -0,0 +1,161 @@
+package pcg
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+func TestPCG64_RandomBitset(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, nbits := range []int{0, 1, 63, 64, 65, 1000, 100000} {
+		set := pcg.RandomBitset(nbits)
+		if want := (nbits + 63) / 64; len(set) != want {
+			t.Fatalf("RandomBitset(%d) len = %d; want %d", nbits, len(set), want)
+		}
+
+		ones := 0
+		for _, w := range set {
+			ones += bits.OnesCount64(w)
+		}
+		if rem := nbits % 64; rem != 0 {
+			if padding := set[len(set)-1] >> rem; padding != 0 {
+				t.Errorf("RandomBitset(%d) padding bits = %#x; want 0", nbits, padding)
+			}
+		}
+		if nbits >= 1000 {
+			half := nbits / 2
+			if abs(ones-half) > half/20 {
+				t.Errorf("RandomBitset(%d) popcount = %d; want ~%d", nbits, ones, half)
+			}
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("RandomBitset(-1) did not panic")
+		}
+	}()
+	pcg.RandomBitset(-1)
+}
+
+func TestPCG64_RandomSubset(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, n := range []int{0, 1, 5, 32, 63, 64} {
+		const draws = 20000
+		ones := 0
+		for i := 0; i < draws; i++ {
+			mask := pcg.RandomSubset(n)
+			if n < 64 && mask>>n != 0 {
+				t.Fatalf("RandomSubset(%d) = %#x; want no bits set at or above bit %d", n, mask, n)
+			}
+			ones += bits.OnesCount64(mask)
+		}
+		if got, want := float64(ones)/draws, float64(n)/2; math.Abs(got-want) > 0.05*want+0.01 {
+			t.Errorf("RandomSubset(%d) mean popcount = %f; want ~%f", n, got, want)
+		}
+	}
+
+	for _, n := range []int{-1, 65} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("RandomSubset(%d) did not panic", n)
+				}
+			}()
+			pcg.RandomSubset(n)
+		}()
+	}
+}
+
+func TestPCG64_CoinFlips(t *testing.T) {
+	a := NewPCG64(42, 54).CoinFlips(200)
+	b := NewPCG64(42, 54).CoinFlips(200)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("CoinFlips(200)[%d] differs between identically seeded generators", i)
+		}
+	}
+
+	// flips are the bits of consecutive Uint64 draws, least significant bit first
+	ref := NewPCG64(42, 54)
+	for w := 0; w < 3; w++ {
+		word := ref.Uint64()
+		for i := 0; i < 64; i++ {
+			if got, want := a[w*64+i], word>>i&1 == 1; got != want {
+				t.Fatalf("CoinFlips(200)[%d] = %v; want %v", w*64+i, got, want)
+			}
+		}
+	}
+
+	const n = 1000000
+	heads := 0
+	for _, f := range NewPCG64(12345, 67890).CoinFlips(n) {
+		if f {
+			heads++
+		}
+	}
+	if abs(heads-n/2) > n/50 {
+		t.Errorf("CoinFlips(%d) returned %d heads; want ~%d", n, heads, n/2)
+	}
+
+	if res := NewPCG64(1, 2).CoinFlips(0); len(res) != 0 {
+		t.Errorf("CoinFlips(0) = %v; want an empty slice", res)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("CoinFlips(-1) did not panic")
+		}
+	}()
+	NewPCG64(1, 2).CoinFlips(-1)
+}
+
+var sinkBools []bool
+
+func BenchmarkPCG64_CoinFlips(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	for i := 0; i < b.N; i++ {
+		sinkBools = pcg.CoinFlips(1024)
+	}
+}
+
+func BenchmarkPCG64_CoinFlipsPerDraw(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	for i := 0; i < b.N; i++ {
+		res := make([]bool, 1024)
+		for j := range res {
+			res[j] = pcg.Uint64()&1 == 1
+		}
+		sinkBools = res
+	}
+}
+
+func TestBitStream(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	ref := NewPCG64(42, 54)
+	bs := pcg.BitStream()
+
+	// each refill consumes exactly one Uint64, least significant bit first
+	for w := 0; w < 3; w++ {
+		word := ref.Uint64()
+		for i := 0; i < 64; i++ {
+			if got, want := bs.NextBit(), word>>i&1 == 1; got != want {
+				t.Fatalf("word %d bit %d = %v; want %v", w, i, got, want)
+			}
+		}
+	}
+
+	const n = 1000000
+	ones := 0
+	for i := 0; i < n; i++ {
+		if bs.NextBit() {
+			ones++
+		}
+	}
+	if abs(ones-n/2) > n/50 {
+		t.Errorf("NextBit() returned true %d times out of %d; want ~%d", ones, n, n/2)
+	}
+}
@@ -0,0 +1,34 @@
+package pcg
+
+// BoundedU64 draws unbiased values in [0, bound) for a fixed bound.
+// The rejection threshold is computed once, so repeated draws with the same bound
+// avoid the extra division that Uint64n performs on every call.
+type BoundedU64 struct {
+	p         *PCG64
+	bound     uint64
+	threshold uint64
+}
+
+// NewBounded returns a BoundedU64 drawing from p with the given bound.
+// It panics if bound is 0.
+func (p *PCG64) NewBounded(bound uint64) *BoundedU64 {
+	if bound == 0 {
+		panic("invalid argument to NewBounded")
+	}
+	return &BoundedU64{
+		p:         p,
+		bound:     bound,
+		threshold: -bound % bound,
+	}
+}
+
+// Next returns a pseudorandom number in the range [0, bound).
+// It yields the same values as Uint64n(bound) on the underlying generator.
+func (b *BoundedU64) Next() uint64 {
+	for {
+		r := b.p.Uint64()
+		if r >= b.threshold {
+			return r % b.bound
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package pcg
+
+import "testing"
+
+func TestBoundedU64_MatchesUint64n(t *testing.T) {
+	for _, bound := range []uint64{1, 6, 52, 1<<63 + 1} {
+		bounded := NewPCG64(42, 54).NewBounded(bound)
+		ref := NewPCG64(42, 54)
+		for i := 0; i < 1000; i++ {
+			if got, want := bounded.Next(), ref.Uint64n(bound); got != want {
+				t.Fatalf("#%d: NewBounded(%d).Next() = %d; want %d", i, bound, got, want)
+			}
+		}
+	}
+}
+
+func TestBoundedU64_Uniform(t *testing.T) {
+	const (
+		bound = 6
+		n     = 600000
+	)
+	b := NewPCG64(12345, 67890).NewBounded(bound)
+	counts := make([]int, bound)
+	for i := 0; i < n; i++ {
+		v := b.Next()
+		if v >= bound {
+			t.Fatalf("Next() = %d; want a value in [0, %d)", v, bound)
+		}
+		counts[v]++
+	}
+
+	want := n / bound
+	for v, c := range counts {
+		if abs(c-want) > want/50 {
+			t.Errorf("value %d drawn %d times; want ~%d", v, c, want)
+		}
+	}
+}
+
+func TestNewBounded_Zero(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("NewBounded(0) did not panic")
+		}
+	}()
+	NewPCG64(1, 2).NewBounded(0)
+}
+
+// benchBound64 is a variable so the compiler cannot fold the bound into Uint64n.
+var benchBound64 uint64 = 52
+
+func BenchmarkBoundedU64_Next(b *testing.B) {
+	bounded := NewPCG64(42, 54).NewBounded(benchBound64)
+	for i := 0; i < b.N; i++ {
+		bounded.Next()
+	}
+}
+
+func BenchmarkPCG64_Uint64nRepeated(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	for i := 0; i < b.N; i++ {
+		pcg.Uint64n(benchBound64)
+	}
+}
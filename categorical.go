@@ -0,0 +1,54 @@
+package pcg
+
+import (
+	"math"
+	"sort"
+)
+
+// A Categorical draws indices from a fixed categorical distribution using a PCG64 generator.
+type Categorical struct {
+	p   *PCG64
+	cdf []float64 // cumulative unnormalized weights; cdf[len(cdf)-1] is the total
+}
+
+// NewCategoricalLogits returns a Categorical whose index i is drawn with probability
+// softmax(logits)[i] = exp(logits[i]) / sum_j exp(logits[j]).
+// The softmax is computed stably by subtracting the largest logit before exponentiating,
+// so logits of any magnitude work without overflow. A logit of -Inf gives probability 0.
+// It panics if logits is empty, contains NaN or +Inf, or is entirely -Inf.
+func NewCategoricalLogits(p *PCG64, logits []float64) *Categorical {
+	if len(logits) == 0 {
+		panic("invalid argument to NewCategoricalLogits")
+	}
+
+	max := math.Inf(-1)
+	for _, l := range logits {
+		if math.IsNaN(l) || math.IsInf(l, 1) {
+			panic("invalid argument to NewCategoricalLogits")
+		}
+		max = math.Max(max, l)
+	}
+	if math.IsInf(max, -1) {
+		panic("invalid argument to NewCategoricalLogits")
+	}
+
+	cdf := make([]float64, len(logits))
+	sum := 0.0
+	for i, l := range logits {
+		sum += math.Exp(l - max)
+		cdf[i] = sum
+	}
+	return &Categorical{p: p, cdf: cdf}
+}
+
+// Sample returns an index in [0, len(logits)) drawn from the distribution.
+func (c *Categorical) Sample() int {
+	total := c.cdf[len(c.cdf)-1]
+	u := c.p.Float64() * total
+	// the first index whose cumulative weight exceeds u; zero-weight entries are never chosen
+	i := sort.Search(len(c.cdf), func(i int) bool { return c.cdf[i] > u })
+	if i == len(c.cdf) { // guard against rounding right below total
+		i = len(c.cdf) - 1
+	}
+	return i
+}
@@ -0,0 +1,73 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCategoricalLogits(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := [][]float64{
+		{0, 0, 0, 0},
+		{1, 2, 3},
+		{1000, 1001, 999},   // would overflow a naive exp
+		{-1000, -999, -998}, // would underflow a naive exp
+		{2, math.Inf(-1), 0},
+	}
+
+	for _, logits := range tests {
+		max := math.Inf(-1)
+		for _, l := range logits {
+			max = math.Max(max, l)
+		}
+		want := make([]float64, len(logits))
+		sum := 0.0
+		for i, l := range logits {
+			want[i] = math.Exp(l - max)
+			sum += want[i]
+		}
+
+		c := NewCategoricalLogits(pcg, logits)
+		const n = 100000
+		counts := make([]int, len(logits))
+		for i := 0; i < n; i++ {
+			counts[c.Sample()]++
+		}
+
+		for i := range counts {
+			got, w := float64(counts[i])/n, want[i]/sum
+			if math.Abs(got-w) > 0.05*w+0.002 {
+				t.Errorf("logits %v: frequency of %d = %f; want ~%f", logits, i, got, w)
+			}
+			if w == 0 && counts[i] != 0 {
+				t.Errorf("logits %v: index %d with -Inf logit drawn %d times; want 0", logits, i, counts[i])
+			}
+		}
+	}
+}
+
+func TestCategoricalLogits_Invalid(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name   string
+		logits []float64
+	}{
+		{"empty", nil},
+		{"NaN", []float64{0, math.NaN()}},
+		{"+Inf", []float64{0, math.Inf(1)}},
+		{"all -Inf", []float64{math.Inf(-1), math.Inf(-1)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("NewCategoricalLogits with %s logits did not panic", tc.name)
+				}
+			}()
+			NewCategoricalLogits(pcg, tc.logits)
+		})
+	}
+}
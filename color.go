@@ -0,0 +1,10 @@
+package pcg
+
+import "image/color"
+
+// RGBA returns a random opaque color: the red, green and blue channels are the top three
+// bytes of a single Uint64 draw, and alpha is always 255.
+func (p *PCG64) RGBA() color.RGBA {
+	v := p.Uint64()
+	return color.RGBA{R: uint8(v >> 56), G: uint8(v >> 48), B: uint8(v >> 40), A: 255}
+}
@@ -0,0 +1,27 @@
+package pcg
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestPCG64_RGBA(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	want := []color.RGBA{
+		{R: 0x6a, G: 0xe1, B: 0xfd, A: 0xff},
+		{R: 0x3d, G: 0xe4, B: 0x09, A: 0xff},
+		{R: 0x70, G: 0x2c, B: 0x24, A: 0xff},
+	}
+	for i, w := range want {
+		if got := pcg.RGBA(); got != w {
+			t.Errorf("#%d: RGBA() = %#v; want %#v", i, got, w)
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		if c := pcg.RGBA(); c.A != 255 {
+			t.Fatalf("RGBA() = %#v; want an opaque color", c)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package pcg
+
+// A Deck is a deck of n cards, numbered 0 to n-1, shuffled and dealt using a PCG64 generator.
+// It keeps a deal cursor: dealt cards leave the deck until the next Shuffle.
+type Deck struct {
+	p     *PCG64
+	cards []int
+	next  int // index of the top undealt card
+}
+
+// NewDeck returns a deck of n cards in order 0, 1, ..., n-1 with nothing dealt.
+// Call Shuffle before dealing for a random order.
+// It panics if n < 0.
+func NewDeck(p *PCG64, n int) *Deck {
+	if n < 0 {
+		panic("invalid argument to NewDeck")
+	}
+
+	cards := make([]int, n)
+	for i := range cards {
+		cards[i] = i
+	}
+	return &Deck{p: p, cards: cards}
+}
+
+// Shuffle gathers all n cards, including any already dealt, and shuffles them.
+func (d *Deck) Shuffle() {
+	d.next = 0
+	d.p.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+}
+
+// Remaining returns the number of cards not yet dealt.
+func (d *Deck) Remaining() int {
+	return len(d.cards) - d.next
+}
+
+// Cut moves the top at undealt cards, in order, beneath the rest of the undealt cards.
+// It panics if at < 0 or at > Remaining().
+func (d *Deck) Cut(at int) {
+	if at < 0 || at > d.Remaining() {
+		panic("invalid argument to Cut")
+	}
+
+	rest := d.cards[d.next:]
+	top := append([]int(nil), rest[:at]...)
+	copy(rest, rest[at:])
+	copy(rest[len(rest)-at:], top)
+}
+
+// Deal removes the top k cards from the deck and returns them in order.
+// If fewer than k cards remain, the whole deck is reshuffled first, as a dealer would
+// with a fresh deck, so Deal never fails for k <= n.
+// It panics if k < 0 or k exceeds the size of the deck.
+func (d *Deck) Deal(k int) []int {
+	if k < 0 || k > len(d.cards) {
+		panic("invalid argument to Deal")
+	}
+
+	if k > d.Remaining() {
+		d.Shuffle()
+	}
+	hand := append([]int(nil), d.cards[d.next:d.next+k]...)
+	d.next += k
+	return hand
+}
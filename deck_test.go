@@ -0,0 +1,68 @@
+package pcg
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDeck_FullDeal(t *testing.T) {
+	const n = 52
+	d := NewDeck(NewPCG64(42, 54), n)
+	d.Shuffle()
+
+	var dealt []int
+	for d.Remaining() > 0 {
+		dealt = append(dealt, d.Deal(min(5, d.Remaining()))...)
+	}
+	if len(dealt) != n {
+		t.Fatalf("dealt %d cards; want %d", len(dealt), n)
+	}
+	sorted := append([]int(nil), dealt...)
+	sort.Ints(sorted)
+	if !reflect.DeepEqual(sorted, identity(n)) {
+		t.Errorf("full deal = %v; want each of 0..%d exactly once", dealt, n-1)
+	}
+	if reflect.DeepEqual(dealt, identity(n)) {
+		t.Errorf("full deal after Shuffle() = %v; want a shuffled order", dealt)
+	}
+}
+
+func TestDeck_Cut(t *testing.T) {
+	d := NewDeck(NewPCG64(1, 2), 6)
+	d.Deal(1)
+	d.Cut(2)
+	if got, want := d.Deal(5), []int{3, 4, 5, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Deal(5) after Deal(1) and Cut(2) = %v; want %v", got, want)
+	}
+
+	d.Cut(0) // nothing left to cut
+	for _, at := range []int{-1, 1} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Cut(%d) with no cards left did not panic", at)
+				}
+			}()
+			d.Cut(at)
+		}()
+	}
+}
+
+func TestDeck_DealReshuffles(t *testing.T) {
+	d := NewDeck(NewPCG64(3, 4), 10)
+	d.Shuffle()
+	d.Deal(8)
+
+	hand := d.Deal(5)
+	if len(hand) != 5 || d.Remaining() != 5 {
+		t.Errorf("Deal(5) with 2 left = %v, Remaining() = %d; want 5 cards and 5 left", hand, d.Remaining())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Deal(11) on a 10-card deck did not panic")
+		}
+	}()
+	d.Deal(11)
+}
@@ -0,0 +1,527 @@
+package pcg
+
+import "math"
+
+// NormFloat64 returns a normally distributed float64 with mean 0 and standard deviation 1.
+// It uses the Box-Muller transform, which yields two independent normals per pair of uniforms:
+// the first is returned and the second is cached for the next call.
+// Seeding the generator drops a cached value; MarshalBinary saves it and UnmarshalBinary
+// restores it, so a restored generator continues the same sequence of normals.
+func (p *PCG64) NormFloat64() float64 {
+	if p.hasSpare {
+		p.hasSpare = false
+		return p.spare
+	}
+	x, y := p.NormFloat64Pair()
+	p.spare, p.hasSpare = y, true
+	return x
+}
+
+// NormFloat64Pair returns two independent normally distributed float64s with mean 0
+// and standard deviation 1, generated by one Box-Muller transform over two uniform draws.
+// It neither uses nor fills the spare cached by NormFloat64.
+func (p *PCG64) NormFloat64Pair() (float64, float64) {
+	u1 := 1 - p.Float64() // (0, 1] to avoid log(0)
+	u2 := p.Float64()
+	r := math.Sqrt(-2 * math.Log(u1))
+	sin, cos := math.Sincos(2 * math.Pi * u2)
+	return r * cos, r * sin
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate 1 (mean 1).
+// It uses the inverse CDF -ln(U) with U drawn from (0, 1].
+func (p *PCG64) ExpFloat64() float64 {
+	return -math.Log(p.Float64OpenClosed())
+}
+
+// Exponential returns an exponentially distributed float64 with the given rate,
+// so the mean is 1/rate. It is ExpFloat64() / rate.
+// It panics if rate <= 0.
+func (p *PCG64) Exponential(rate float64) float64 {
+	if !(rate > 0) {
+		panic("invalid argument to Exponential")
+	}
+	return p.ExpFloat64() / rate
+}
+
+// InverseTransform draws a sample from any continuous distribution given its inverse CDF.
+// It passes U drawn from the open interval (0, 1) to invCDF, so quantile functions that
+// diverge at 0 or 1 (such as -ln(1-u)/rate for the exponential) never return an infinity.
+func (p *PCG64) InverseTransform(invCDF func(u float64) float64) float64 {
+	return invCDF(p.Float64OpenOpen())
+}
+
+// FillNormal fills dst with normally distributed values with mean mu and standard deviation sigma.
+// Slots are filled two at a time from NormFloat64Pair; an odd final slot is filled by NormFloat64.
+// It panics if sigma < 0.
+func (p *PCG64) FillNormal(dst []float64, mu, sigma float64) {
+	if !(sigma >= 0) {
+		panic("invalid argument to FillNormal")
+	}
+
+	i := 0
+	for ; i+1 < len(dst); i += 2 {
+		x, y := p.NormFloat64Pair()
+		dst[i] = mu + sigma*x
+		dst[i+1] = mu + sigma*y
+	}
+	if i < len(dst) {
+		dst[i] = mu + sigma*p.NormFloat64()
+	}
+}
+
+// TruncatedNormal returns a normally distributed float64 with mean mu and standard deviation
+// sigma, conditioned to lie in [a, b]. Either bound may be infinite.
+// It follows Robert (1995), "Simulation of truncated normal variables": intervals containing
+// the mean use plain normal rejection when wide and a uniform proposal when narrow, and
+// intervals in a tail use a uniform proposal when narrow and an exponential proposal otherwise,
+// so the expected number of draws stays bounded however far out [a, b] lies.
+// It panics if sigma <= 0 or a >= b.
+func (p *PCG64) TruncatedNormal(mu, sigma, a, b float64) float64 {
+	if !(sigma > 0) || !(a < b) {
+		panic("invalid argument to TruncatedNormal")
+	}
+
+	lo, hi := (a-mu)/sigma, (b-mu)/sigma
+	if hi <= 0 { // mirror the lower tail onto the upper one
+		return mu - sigma*p.truncatedStdNormal(-hi, -lo)
+	}
+	return mu + sigma*p.truncatedStdNormal(lo, hi)
+}
+
+// truncatedStdNormal samples a standard normal restricted to [lo, hi], where hi > 0.
+func (p *PCG64) truncatedStdNormal(lo, hi float64) float64 {
+	if lo <= 0 {
+		if hi-lo >= math.Sqrt(2*math.Pi) {
+			for {
+				if z := p.NormFloat64(); z >= lo && z <= hi {
+					return z
+				}
+			}
+		}
+		for {
+			z := lo + (hi-lo)*p.Float64()
+			if p.Float64() <= math.Exp(-z*z/2) {
+				return z
+			}
+		}
+	}
+
+	// lo > 0: the optimal exponential rate from Robert's paper
+	rate := (lo + math.Sqrt(lo*lo+4)) / 2
+	if hi-lo < 2*math.Sqrt(math.E)/rate*math.Exp((lo*lo-lo*math.Sqrt(lo*lo+4))/4) {
+		for {
+			z := lo + (hi-lo)*p.Float64()
+			if p.Float64() <= math.Exp((lo*lo-z*z)/2) {
+				return z
+			}
+		}
+	}
+	for {
+		z := lo + p.ExpFloat64()/rate
+		if z > hi {
+			continue
+		}
+		if p.Float64() <= math.Exp(-(z-rate)*(z-rate)/2) {
+			return z
+		}
+	}
+}
+
+// LogNormal returns a log-normally distributed float64, i.e. exp(mu + sigma*N)
+// where N is a standard normal variate. The median of the distribution is exp(mu).
+// It panics if sigma < 0 or sigma is NaN.
+func (p *PCG64) LogNormal(mu, sigma float64) float64 {
+	if !(sigma >= 0) {
+		panic("invalid argument to LogNormal")
+	}
+	return math.Exp(mu + sigma*p.NormFloat64())
+}
+
+// Bernoulli returns true with probability prob, by comparing a Float64Full draw against it.
+// Bernoulli(0) is always false and Bernoulli(1) always true.
+// It panics if prob is not in [0, 1].
+func (p *PCG64) Bernoulli(prob float64) bool {
+	if !(prob >= 0 && prob <= 1) {
+		panic("invalid argument to Bernoulli")
+	}
+	return p.Float64Full() < prob
+}
+
+// NormMixture returns a sample from a mixture of two normal distributions: with probability
+// weight from N(mu1, sigma1²), and otherwise from N(mu2, sigma2²). Well separated components
+// give a bimodal distribution. The component is picked with Bernoulli and the value drawn
+// with NormFloat64.
+// It panics if weight is not in [0, 1] or either sigma is negative.
+func (p *PCG64) NormMixture(mu1, sigma1, mu2, sigma2, weight float64) float64 {
+	if !(weight >= 0 && weight <= 1) || !(sigma1 >= 0) || !(sigma2 >= 0) {
+		panic("invalid argument to NormMixture")
+	}
+	if p.Bernoulli(weight) {
+		return mu1 + sigma1*p.NormFloat64()
+	}
+	return mu2 + sigma2*p.NormFloat64()
+}
+
+// Weibull returns a Weibull distributed float64 with scale lambda and shape k.
+// It uses the inverse CDF lambda * (-ln(U))^(1/k) over Float64Full.
+// It panics if lambda <= 0, k <= 0, or either is NaN.
+func (p *PCG64) Weibull(lambda, k float64) float64 {
+	if !(lambda > 0) || !(k > 0) {
+		panic("invalid argument to Weibull")
+	}
+	u := p.Float64Full()
+	for u == 0 {
+		u = p.Float64Full()
+	}
+	return lambda * math.Pow(-math.Log(u), 1/k)
+}
+
+// Pareto returns a Pareto distributed float64 with scale xm and shape alpha.
+// It uses the inverse CDF xm / U^(1/alpha) over Float64Full.
+// It panics if xm <= 0, alpha <= 0, or either is NaN.
+func (p *PCG64) Pareto(xm, alpha float64) float64 {
+	if !(xm > 0) || !(alpha > 0) {
+		panic("invalid argument to Pareto")
+	}
+	u := p.Float64Full()
+	for u == 0 {
+		u = p.Float64Full()
+	}
+	return xm / math.Pow(u, 1/alpha)
+}
+
+// Levy returns a Lévy distributed float64 with location mu and scale c.
+// It uses the inverse CDF mu + c / (2 * erfcinv(U)^2), which equals mu + c / Z^2 for the
+// standard normal quantile Z = Φ^-1(1 - U/2), with U drawn from the open interval (0, 1).
+// The distribution is heavy-tailed: its mean and variance do not exist, so summarize samples
+// by quantiles such as the median, mu + c / (2 * erfcinv(0.5)^2).
+// It panics if c <= 0.
+func (p *PCG64) Levy(mu, c float64) float64 {
+	if !(c > 0) {
+		panic("invalid argument to Levy")
+	}
+	e := math.Erfcinv(p.Float64OpenOpen())
+	return mu + c/(2*e*e)
+}
+
+// Gamma returns a gamma distributed float64 with the given shape and scale.
+// It uses the Marsaglia-Tsang method, boosting shapes below 1 by drawing
+// Gamma(shape+1) and scaling it by U^(1/shape).
+// It panics if shape <= 0, scale <= 0, or either is NaN.
+func (p *PCG64) Gamma(shape, scale float64) float64 {
+	if !(shape > 0) || !(scale > 0) {
+		panic("invalid argument to Gamma")
+	}
+	if shape < 1 {
+		u := p.Float64Full()
+		for u == 0 {
+			u = p.Float64Full()
+		}
+		return p.Gamma(shape+1, scale) * math.Pow(u, 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := p.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := p.Float64Full()
+		if u < 1-0.0331*x*x*x*x {
+			return d * v * scale
+		}
+		if u > 0 && math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v * scale
+		}
+	}
+}
+
+// Dirichlet returns a random probability vector drawn from the Dirichlet distribution
+// with concentration parameters alpha. The result has len(alpha) components summing to 1.
+// It panics if alpha is empty or any component is not positive.
+func (p *PCG64) Dirichlet(alpha []float64) []float64 {
+	if len(alpha) == 0 {
+		panic("invalid argument to Dirichlet")
+	}
+	for _, a := range alpha {
+		if !(a > 0) {
+			panic("invalid argument to Dirichlet")
+		}
+	}
+
+	res := make([]float64, len(alpha))
+	sum := 0.0
+	for i, a := range alpha {
+		res[i] = p.Gamma(a, 1)
+		sum += res[i]
+	}
+	for i := range res {
+		res[i] /= sum
+	}
+	return res
+}
+
+// Binomial returns the number of successes in n independent trials that each
+// succeed with probability prob.
+// Large n is reduced with Knuth's beta-splitting recursion, so the cost grows
+// logarithmically with n rather than linearly.
+// It panics if n < 0 or prob is not in [0, 1].
+func (p *PCG64) Binomial(n int64, prob float64) int64 {
+	if n < 0 || !(prob >= 0 && prob <= 1) {
+		panic("invalid argument to Binomial")
+	}
+	if prob > 0.5 {
+		return n - p.Binomial(n, 1-prob)
+	}
+
+	var k int64
+	for n > 40 && prob > 0 {
+		// The a-th smallest of n uniforms is Beta(a, b) distributed.
+		a := 1 + n/2
+		b := n + 1 - a
+		ga := p.Gamma(float64(a), 1)
+		x := ga / (ga + p.Gamma(float64(b), 1))
+		if x >= prob {
+			n = a - 1
+			prob /= x
+		} else {
+			k += a
+			n = b - 1
+			prob = (prob - x) / (1 - x)
+		}
+	}
+
+	for i := int64(0); i < n; i++ {
+		if p.Float64Full() < prob {
+			k++
+		}
+	}
+	return k
+}
+
+// Multinomial distributes n trials over the categories defined by probs and
+// returns the number of trials that landed in each category.
+// probs should sum to ~1; it is normalized by its sum before sampling.
+// Each count is drawn from a binomial conditioned on the trials left over
+// by the previous categories, so the cost does not grow linearly with n.
+// It panics if n < 0, probs is empty, or any probability is negative.
+func (p *PCG64) Multinomial(n int, probs []float64) []int {
+	if n < 0 || len(probs) == 0 {
+		panic("invalid argument to Multinomial")
+	}
+	mass := 0.0
+	for _, pr := range probs {
+		if !(pr >= 0) {
+			panic("invalid argument to Multinomial")
+		}
+		mass += pr
+	}
+	if mass <= 0 {
+		panic("invalid argument to Multinomial")
+	}
+
+	counts := make([]int, len(probs))
+	left := int64(n)
+	for i, pr := range probs {
+		if left == 0 || mass <= 0 {
+			break
+		}
+		cond := pr / mass
+		if cond > 1 || i == len(probs)-1 {
+			cond = 1
+		}
+		c := p.Binomial(left, cond)
+		counts[i] = int(c)
+		left -= c
+		mass -= pr
+	}
+	return counts
+}
+
+// Laplace returns a Laplace distributed float64 with location mu and scale b.
+// It uses the inverse CDF mu - b*sign(U)*ln(1-2|U|) with U uniform in (-0.5, 0.5).
+// It panics if b <= 0.
+func (p *PCG64) Laplace(mu, b float64) float64 {
+	if !(b > 0) {
+		panic("invalid argument to Laplace")
+	}
+	u := p.Float64Full()
+	for u == 0 {
+		u = p.Float64Full()
+	}
+	u -= 0.5
+	if u < 0 {
+		return mu + b*math.Log(1+2*u)
+	}
+	return mu - b*math.Log(1-2*u)
+}
+
+// Rayleigh returns a Rayleigh distributed float64 with scale sigma.
+// It uses the inverse CDF sigma * sqrt(-2*ln(U)).
+// It panics if sigma <= 0.
+func (p *PCG64) Rayleigh(sigma float64) float64 {
+	if !(sigma > 0) {
+		panic("invalid argument to Rayleigh")
+	}
+	u := p.Float64Full()
+	for u == 0 {
+		u = p.Float64Full()
+	}
+	return sigma * math.Sqrt(-2*math.Log(u))
+}
+
+// ChiSquared returns a chi-squared distributed float64 with k degrees of freedom,
+// drawn as Gamma(k/2, 2).
+// It panics if k <= 0.
+func (p *PCG64) ChiSquared(k float64) float64 {
+	if !(k > 0) {
+		panic("invalid argument to ChiSquared")
+	}
+	return p.Gamma(k/2, 2)
+}
+
+// StudentT returns a Student's t distributed float64 with nu degrees of freedom,
+// drawn as NormFloat64() / sqrt(ChiSquared(nu)/nu).
+// It panics if nu <= 0.
+func (p *PCG64) StudentT(nu float64) float64 {
+	if !(nu > 0) {
+		panic("invalid argument to StudentT")
+	}
+	return p.NormFloat64() / math.Sqrt(p.ChiSquared(nu)/nu)
+}
+
+// FDistribution returns an F distributed float64 with d1 and d2 degrees of freedom,
+// drawn as (ChiSquared(d1)/d1) / (ChiSquared(d2)/d2).
+// It panics if d1 <= 0 or d2 <= 0.
+func (p *PCG64) FDistribution(d1, d2 float64) float64 {
+	if !(d1 > 0) || !(d2 > 0) {
+		panic("invalid argument to FDistribution")
+	}
+	return (p.ChiSquared(d1) / d1) / (p.ChiSquared(d2) / d2)
+}
+
+// Poisson returns a Poisson distributed count with mean lambda.
+// Small means use Knuth's multiplication method; means of 10 and above use
+// Hörmann's PTRS transformed rejection, whose cost does not grow with lambda.
+// It panics if lambda < 0.
+func (p *PCG64) Poisson(lambda float64) int64 {
+	if !(lambda >= 0) || math.IsInf(lambda, 1) {
+		panic("invalid argument to Poisson")
+	}
+	if lambda == 0 {
+		return 0
+	}
+
+	if lambda < 10 {
+		limit := math.Exp(-lambda)
+		var k int64
+		prod := p.Float64Full()
+		for prod > limit {
+			k++
+			prod *= p.Float64Full()
+		}
+		return k
+	}
+
+	// ref: W. Hörmann, "The transformed rejection method for generating Poisson random variables"
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := p.Float64Full() - 0.5
+		v := p.Float64Full()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int64(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lg {
+			return int64(k)
+		}
+	}
+}
+
+// NegativeBinomial returns the number of failures before the r-th success in trials that
+// succeed with probability prob; r may be fractional. It is drawn as a Gamma-Poisson mixture:
+// Poisson(λ) with λ ~ Gamma(r, (1-prob)/prob), so the mean is r*(1-prob)/prob.
+// It panics if r <= 0 or prob is not in (0, 1).
+func (p *PCG64) NegativeBinomial(r, prob float64) int64 {
+	if !(r > 0) || !(prob > 0 && prob < 1) {
+		panic("invalid argument to NegativeBinomial")
+	}
+	return p.Poisson(p.Gamma(r, (1-prob)/prob))
+}
+
+// Hypergeometric returns the number of "good" items drawn when sampling draws items without
+// replacement from a population of good + bad items.
+// It inverts the CDF with a sequential search that starts at the mode and walks outward,
+// so the expected cost grows with the standard deviation rather than the population size.
+// It panics if any argument is negative or draws > good+bad.
+func (p *PCG64) Hypergeometric(good, bad, draws int64) int64 {
+	// draws-good cannot overflow once both are non-negative, unlike good+bad
+	if good < 0 || bad < 0 || draws < 0 || draws-good > bad {
+		panic("invalid argument to Hypergeometric")
+	}
+
+	kmin := max(0, draws-bad)
+	kmax := min(draws, good)
+	if kmin == kmax {
+		return kmin
+	}
+
+	g, b, n := float64(good), float64(bad), float64(draws)
+	total := g + b
+	mode := int64((n + 1) * (g + 1) / (total + 2))
+	mode = min(max(mode, kmin), kmax)
+
+	// P(mode) = C(good, mode) * C(bad, draws-mode) / C(total, draws)
+	m := float64(mode)
+	pMode := math.Exp(logChoose(g, m) + logChoose(b, n-m) - logChoose(total, n))
+
+	u := p.Float64Full() - pMode
+	if u <= 0 {
+		return mode
+	}
+
+	lo, hi := mode-1, mode+1
+	pLo, pHi := pMode, pMode
+	for lo >= kmin || hi <= kmax {
+		if hi <= kmax {
+			k := float64(hi - 1)
+			pHi *= (g - k) * (n - k) / ((k + 1) * (b - n + k + 1))
+			if u -= pHi; u <= 0 {
+				return hi
+			}
+			hi++
+		}
+		if lo >= kmin {
+			k := float64(lo + 1)
+			pLo *= k * (b - n + k) / ((g - k + 1) * (n - k + 1))
+			if u -= pLo; u <= 0 {
+				return lo
+			}
+			lo--
+		}
+	}
+	// only reachable through floating point rounding of the probabilities
+	return mode
+}
+
+// logChoose returns the natural logarithm of the binomial coefficient C(n, k).
+func logChoose(n, k float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(k + 1)
+	c, _ := math.Lgamma(n - k + 1)
+	return a - b - c
+}
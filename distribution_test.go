@@ -0,0 +1,982 @@
+package pcg
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+func median(samples []float64) float64 {
+	sorted := make([]float64, len(samples))
+	copy(sorted, samples)
+	sort.Float64s(sorted)
+	return sorted[len(sorted)/2]
+}
+
+func meanAndVariance(samples []float64) (float64, float64) {
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	mean := sum / float64(len(samples))
+
+	var sq float64
+	for _, s := range samples {
+		sq += (s - mean) * (s - mean)
+	}
+	return mean, sq / float64(len(samples))
+}
+
+func TestNormFloat64(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	samples := make([]float64, 200000)
+	for i := range samples {
+		samples[i] = pcg.NormFloat64()
+	}
+
+	mean, variance := meanAndVariance(samples)
+	if math.Abs(mean) > 0.01 {
+		t.Errorf("NormFloat64() mean = %f; want ~0", mean)
+	}
+	if math.Abs(variance-1) > 0.05 {
+		t.Errorf("NormFloat64() variance = %f; want ~1", variance)
+	}
+}
+
+func TestNormFloat64Pair_Uncorrelated(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	const n = 200000
+
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i := 0; i < n; i++ {
+		xs[i], ys[i] = pcg.NormFloat64Pair()
+	}
+
+	mx, vx := meanAndVariance(xs)
+	my, vy := meanAndVariance(ys)
+	cov := 0.0
+	for i := range xs {
+		cov += (xs[i] - mx) * (ys[i] - my)
+	}
+	cov /= n
+
+	if corr := cov / math.Sqrt(vx*vy); math.Abs(corr) > 0.01 {
+		t.Errorf("NormFloat64Pair() correlation = %f; want ~0", corr)
+	}
+}
+
+func TestNormFloat64_UsesCachedSpare(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	ref := NewPCG64(42, 54)
+
+	for i := 0; i < 10; i++ {
+		x, y := ref.NormFloat64Pair()
+		if got := pcg.NormFloat64(); got != x {
+			t.Fatalf("#%d: NormFloat64() = %f; want first value of the pair %f", i, got, x)
+		}
+		if got := pcg.NormFloat64(); got != y {
+			t.Fatalf("#%d: NormFloat64() = %f; want cached second value of the pair %f", i, got, y)
+		}
+	}
+
+	// seeding drops a pending spare
+	pcg.NormFloat64()
+	pcg.Seed(1, 2, 3, 4)
+	ref.Seed(1, 2, 3, 4)
+	x, _ := ref.NormFloat64Pair()
+	if got := pcg.NormFloat64(); got != x {
+		t.Errorf("NormFloat64() after Seed = %f; want %f", got, x)
+	}
+}
+
+func TestExponential(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, rate := range []float64{0.5, 1, 4} {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.Exponential(rate)
+			if samples[i] < 0 || math.IsInf(samples[i], 0) {
+				t.Fatalf("Exponential(%f) = %f; want a finite non-negative value", rate, samples[i])
+			}
+		}
+
+		mean, _ := meanAndVariance(samples)
+		if want := 1 / rate; math.Abs(mean-want)/want > 0.05 {
+			t.Errorf("Exponential(%f) mean = %f; want ~%f", rate, mean, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Exponential(0) did not panic")
+		}
+	}()
+	pcg.Exponential(0)
+}
+
+func TestInverseTransform(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	const rate = 2.0
+	expInvCDF := func(u float64) float64 { return -math.Log1p(-u) / rate }
+
+	samples := make([]float64, 100000)
+	for i := range samples {
+		samples[i] = pcg.InverseTransform(expInvCDF)
+		if samples[i] < 0 || math.IsInf(samples[i], 0) {
+			t.Fatalf("InverseTransform(exp) = %f; want a finite non-negative value", samples[i])
+		}
+	}
+
+	mean, _ := meanAndVariance(samples)
+	if want := 1 / rate; math.Abs(mean-want)/want > 0.05 {
+		t.Errorf("InverseTransform(exp) mean = %f; want ~%f", mean, want)
+	}
+
+	for i := 0; i < 1000; i++ {
+		pcg.InverseTransform(func(u float64) float64 {
+			if u <= 0 || u >= 1 {
+				t.Fatalf("InverseTransform passed u = %v; want a value in (0, 1)", u)
+			}
+			return u
+		})
+	}
+}
+
+func TestFillNormal(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		mu, sigma float64
+	}{
+		{0, 1},
+		{5, 2},
+		{-1, 0.1},
+	}
+
+	for _, tc := range tests {
+		dst := make([]float64, 100001)
+		pcg.FillNormal(dst, tc.mu, tc.sigma)
+
+		mean, variance := meanAndVariance(dst)
+		if math.Abs(mean-tc.mu) > 0.02*tc.sigma {
+			t.Errorf("FillNormal(%f, %f) mean = %f; want ~%f", tc.mu, tc.sigma, mean, tc.mu)
+		}
+		if sd := math.Sqrt(variance); math.Abs(sd-tc.sigma)/tc.sigma > 0.03 {
+			t.Errorf("FillNormal(%f, %f) stddev = %f; want ~%f", tc.mu, tc.sigma, sd, tc.sigma)
+		}
+	}
+
+	dst := []float64{1, 2, 3}
+	pcg.FillNormal(dst, 7, 0)
+	for i, v := range dst {
+		if v != 7 {
+			t.Errorf("FillNormal(7, 0)[%d] = %f; want 7", i, v)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("FillNormal with sigma < 0 did not panic")
+		}
+	}()
+	pcg.FillNormal(dst, 0, -1)
+}
+
+func BenchmarkFillNormal(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	dst := make([]float64, 1024)
+	for i := 0; i < b.N; i++ {
+		pcg.FillNormal(dst, 0, 1)
+	}
+}
+
+func BenchmarkNormFloat64Loop(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	dst := make([]float64, 1024)
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = pcg.NormFloat64()
+		}
+	}
+}
+
+func TestTruncatedNormal(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	stdPDF := func(x float64) float64 { return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi) }
+	upperTail := func(x float64) float64 { return math.Erfc(x/math.Sqrt2) / 2 }
+
+	tests := []struct {
+		mu, sigma, a, b float64
+	}{
+		{0, 1, -1, 1},             // around the mean
+		{0, 1, -0.1, 0.2},         // narrow, around the mean
+		{10, 2, 4, 30},            // wide
+		{0, 1, 2, math.Inf(1)},    // upper tail
+		{0, 1, 5, 6},              // far tail
+		{0, 1, 8, 8.01},           // narrow, far tail
+		{1, 3, math.Inf(-1), -14}, // lower tail
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 50000)
+		for i := range samples {
+			samples[i] = pcg.TruncatedNormal(tc.mu, tc.sigma, tc.a, tc.b)
+			if samples[i] < tc.a || samples[i] > tc.b {
+				t.Fatalf("TruncatedNormal(%v, %v, %v, %v) = %f; want a value in [a, b]", tc.mu, tc.sigma, tc.a, tc.b, samples[i])
+			}
+		}
+
+		// mean = mu + sigma*(pdf(alpha) - pdf(beta)) / (Phi(beta) - Phi(alpha))
+		alpha, beta := (tc.a-tc.mu)/tc.sigma, (tc.b-tc.mu)/tc.sigma
+		if beta <= 0 {
+			alpha, beta = -beta, -alpha
+		}
+		shift := (stdPDF(alpha) - stdPDF(beta)) / (upperTail(alpha) - upperTail(beta))
+		want := tc.mu + tc.sigma*shift
+		if (tc.b-tc.mu)/tc.sigma <= 0 {
+			want = tc.mu - tc.sigma*shift
+		}
+
+		mean, _ := meanAndVariance(samples)
+		if width := math.Min(tc.b-tc.a, 4*tc.sigma); math.Abs(mean-want) > 0.02*width {
+			t.Errorf("TruncatedNormal(%v, %v, %v, %v) mean = %f; want ~%f", tc.mu, tc.sigma, tc.a, tc.b, mean, want)
+		}
+	}
+
+	for _, tc := range []struct{ sigma, a, b float64 }{{1, 1, 1}, {1, 2, 1}, {0, -1, 1}, {1, math.NaN(), 1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("TruncatedNormal(0, %v, %v, %v) did not panic", tc.sigma, tc.a, tc.b)
+				}
+			}()
+			pcg.TruncatedNormal(0, tc.sigma, tc.a, tc.b)
+		}()
+	}
+}
+
+func TestLogNormal(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+
+	tests := []struct {
+		mu, sigma float64
+	}{
+		{0, 1},
+		{1, 0.5},
+		{-1, 2},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.LogNormal(tc.mu, tc.sigma)
+			if samples[i] <= 0 {
+				t.Fatalf("LogNormal(%f, %f) = %f; want a positive value", tc.mu, tc.sigma, samples[i])
+			}
+		}
+
+		got := median(samples)
+		want := math.Exp(tc.mu)
+		if math.Abs(got-want)/want > 0.03 {
+			t.Errorf("LogNormal(%f, %f) median = %f; want ~%f", tc.mu, tc.sigma, got, want)
+		}
+	}
+}
+
+func TestLogNormal_InvalidSigma(t *testing.T) {
+	for _, sigma := range []float64{-1, math.NaN()} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("LogNormal(0, %v) did not panic", sigma)
+				}
+			}()
+			NewPCG64(1, 2).LogNormal(0, sigma)
+		}()
+	}
+}
+
+func TestBernoulli(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, prob := range []float64{0, 0.1, 0.5, 0.9, 1} {
+		const n = 100000
+		hits := 0
+		for i := 0; i < n; i++ {
+			if pcg.Bernoulli(prob) {
+				hits++
+			}
+		}
+		got := float64(hits) / n
+		if (prob == 0 || prob == 1) && got != prob {
+			t.Errorf("Bernoulli(%v) frequency = %v; want exactly %v", prob, got, prob)
+		}
+		if math.Abs(got-prob) > 0.01 {
+			t.Errorf("Bernoulli(%v) frequency = %f; want ~%v", prob, got, prob)
+		}
+	}
+}
+
+func TestNormMixture(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	// components at -3 and 3 with unit spread; 30% of the mass on the left one
+	const n, weight = 200000, 0.3
+	hist := make([]int, 12) // unit-width bins over [-6, 6)
+	left := 0
+	for i := 0; i < n; i++ {
+		x := pcg.NormMixture(-3, 1, 3, 1, weight)
+		if x < 0 {
+			left++
+		}
+		if b := int(math.Floor(x)) + 6; b >= 0 && b < len(hist) {
+			hist[b]++
+		}
+	}
+
+	if got := float64(left) / n; math.Abs(got-weight) > 0.05*weight {
+		t.Errorf("NormMixture() fraction below 0 = %f; want ~%v", got, weight)
+	}
+
+	// the bins around each mean rise above the trough at 0 on both sides
+	peakL, peakR := hist[2]+hist[3], hist[8]+hist[9] // [-4, -2) and [2, 4)
+	trough := hist[5] + hist[6]                      // [-1, 1)
+	if peakL < 5*trough || peakR < 5*trough {
+		t.Errorf("NormMixture() histogram %v; want two modes around -3 and 3 separated by a trough", hist)
+	}
+	if peakR < 2*peakL {
+		t.Errorf("NormMixture() right mode %d; want about 7/3 of the left mode %d", peakR, peakL)
+	}
+}
+
+func TestBernoulliNormMixture_InvalidParams(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Bernoulli prob<0", func() { pcg.Bernoulli(-0.1) }},
+		{"Bernoulli prob>1", func() { pcg.Bernoulli(1.1) }},
+		{"Bernoulli prob=NaN", func() { pcg.Bernoulli(math.NaN()) }},
+		{"NormMixture weight>1", func() { pcg.NormMixture(0, 1, 0, 1, 2) }},
+		{"NormMixture sigma1<0", func() { pcg.NormMixture(0, -1, 0, 1, 0.5) }},
+		{"NormMixture sigma2<0", func() { pcg.NormMixture(0, 1, 0, -1, 0.5) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestWeibull(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		lambda, k float64
+	}{
+		{1, 1},
+		{2, 0.5},
+		{1.5, 3},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.Weibull(tc.lambda, tc.k)
+		}
+
+		got := median(samples)
+		want := tc.lambda * math.Pow(math.Ln2, 1/tc.k)
+		if math.Abs(got-want)/want > 0.05 {
+			t.Errorf("Weibull(%f, %f) median = %f; want ~%f", tc.lambda, tc.k, got, want)
+		}
+	}
+}
+
+func TestPareto(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		xm, alpha float64
+	}{
+		{1, 1},
+		{2, 3},
+		{0.5, 1.5},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.Pareto(tc.xm, tc.alpha)
+			if samples[i] < tc.xm {
+				t.Fatalf("Pareto(%f, %f) = %f; want a value >= xm", tc.xm, tc.alpha, samples[i])
+			}
+		}
+
+		got := median(samples)
+		want := tc.xm * math.Pow(2, 1/tc.alpha)
+		if math.Abs(got-want)/want > 0.05 {
+			t.Errorf("Pareto(%f, %f) median = %f; want ~%f", tc.xm, tc.alpha, got, want)
+		}
+	}
+}
+
+func TestWeibullPareto_InvalidParams(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Weibull lambda=0", func() { pcg.Weibull(0, 1) }},
+		{"Weibull k<0", func() { pcg.Weibull(1, -1) }},
+		{"Pareto xm=0", func() { pcg.Pareto(0, 1) }},
+		{"Pareto alpha<0", func() { pcg.Pareto(1, -1) }},
+		{"Weibull lambda=NaN", func() { pcg.Weibull(math.NaN(), 1) }},
+		{"Weibull k=NaN", func() { pcg.Weibull(1, math.NaN()) }},
+		{"Pareto xm=NaN", func() { pcg.Pareto(math.NaN(), 1) }},
+		{"Pareto alpha=NaN", func() { pcg.Pareto(1, math.NaN()) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestLevy(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		mu, c float64
+	}{
+		{0, 1},
+		{1, 0.5},
+		{-2, 3},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.Levy(tc.mu, tc.c)
+			if samples[i] < tc.mu {
+				t.Fatalf("Levy(%f, %f) = %f; want a value >= mu", tc.mu, tc.c, samples[i])
+			}
+		}
+
+		e := math.Erfcinv(0.5)
+		want := tc.mu + tc.c/(2*e*e)
+		if got := median(samples); math.Abs(got-want) > 0.1*(want-tc.mu) {
+			t.Errorf("Levy(%f, %f) median = %f; want ~%f", tc.mu, tc.c, got, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Levy(0, 0) did not panic")
+		}
+	}()
+	pcg.Levy(0, 0)
+}
+
+func TestGamma(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		shape, scale float64
+	}{
+		{0.5, 1},
+		{1, 2},
+		{3, 0.5},
+		{9, 1},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.Gamma(tc.shape, tc.scale)
+		}
+
+		mean, _ := meanAndVariance(samples)
+		want := tc.shape * tc.scale
+		if math.Abs(mean-want)/want > 0.05 {
+			t.Errorf("Gamma(%f, %f) mean = %f; want ~%f", tc.shape, tc.scale, mean, want)
+		}
+	}
+}
+
+func TestDirichlet(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	alpha := []float64{1, 2, 3, 4}
+	total := 10.0
+
+	const n = 50000
+	means := make([]float64, len(alpha))
+	for i := 0; i < n; i++ {
+		res := pcg.Dirichlet(alpha)
+		if len(res) != len(alpha) {
+			t.Fatalf("Dirichlet() len = %d; want %d", len(res), len(alpha))
+		}
+
+		sum := 0.0
+		for j, v := range res {
+			sum += v
+			means[j] += v / n
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Fatalf("Dirichlet() sum = %f; want 1", sum)
+		}
+	}
+
+	for i, a := range alpha {
+		want := a / total
+		if math.Abs(means[i]-want) > 0.01 {
+			t.Errorf("Dirichlet() component %d mean = %f; want ~%f", i, means[i], want)
+		}
+	}
+}
+
+func TestGamma_InvalidParams(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"shape=0", func() { pcg.Gamma(0, 1) }},
+		{"scale<0", func() { pcg.Gamma(1, -1) }},
+		{"shape=NaN", func() { pcg.Gamma(math.NaN(), 1) }},
+		{"scale=NaN", func() { pcg.Gamma(1, math.NaN()) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Gamma %s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestDirichlet_InvalidAlpha(t *testing.T) {
+	tests := [][]float64{
+		nil,
+		{1, 0},
+		{-1, 2},
+	}
+
+	for _, alpha := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Dirichlet(%v) did not panic", alpha)
+				}
+			}()
+			NewPCG64(1, 2).Dirichlet(alpha)
+		}()
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		n    int64
+		prob float64
+	}{
+		{0, 0.5},
+		{10, 0},
+		{10, 1},
+		{20, 0.3},
+		{1000, 0.1},
+		{100000, 0.75},
+	}
+
+	for _, tc := range tests {
+		const draws = 20000
+		sum := 0.0
+		for i := 0; i < draws; i++ {
+			k := pcg.Binomial(tc.n, tc.prob)
+			if k < 0 || k > tc.n {
+				t.Fatalf("Binomial(%d, %f) = %d; want a value in [0, %d]", tc.n, tc.prob, k, tc.n)
+			}
+			sum += float64(k)
+		}
+
+		mean := sum / draws
+		want := float64(tc.n) * tc.prob
+		if math.Abs(mean-want) > 0.05*want+0.05 {
+			t.Errorf("Binomial(%d, %f) mean = %f; want ~%f", tc.n, tc.prob, mean, want)
+		}
+	}
+}
+
+func TestMultinomial(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	probs := []float64{0.1, 0.2, 0.3, 0.4}
+
+	const (
+		n     = 1000
+		draws = 5000
+	)
+	means := make([]float64, len(probs))
+	for i := 0; i < draws; i++ {
+		counts := pcg.Multinomial(n, probs)
+		total := 0
+		for j, c := range counts {
+			total += c
+			means[j] += float64(c) / draws
+		}
+		if total != n {
+			t.Fatalf("Multinomial(%d, %v) sum = %d; want %d", n, probs, total, n)
+		}
+	}
+
+	for i, pr := range probs {
+		want := n * pr
+		if math.Abs(means[i]-want)/want > 0.05 {
+			t.Errorf("Multinomial() category %d mean = %f; want ~%f", i, means[i], want)
+		}
+	}
+}
+
+func TestMultinomial_InvalidProbs(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Multinomial with a negative probability did not panic")
+		}
+	}()
+	NewPCG64(1, 2).Multinomial(10, []float64{0.5, -0.1, 0.6})
+}
+
+func TestLaplace(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		mu, b float64
+	}{
+		{0, 1},
+		{2, 0.5},
+		{-3, 2},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 200000)
+		for i := range samples {
+			samples[i] = pcg.Laplace(tc.mu, tc.b)
+		}
+
+		mean, variance := meanAndVariance(samples)
+		if math.Abs(mean-tc.mu) > 0.05*tc.b {
+			t.Errorf("Laplace(%f, %f) mean = %f; want ~%f", tc.mu, tc.b, mean, tc.mu)
+		}
+		want := 2 * tc.b * tc.b
+		if math.Abs(variance-want)/want > 0.05 {
+			t.Errorf("Laplace(%f, %f) variance = %f; want ~%f", tc.mu, tc.b, variance, want)
+		}
+	}
+}
+
+func TestRayleigh(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, sigma := range []float64{0.5, 1, 3} {
+		samples := make([]float64, 200000)
+		for i := range samples {
+			samples[i] = pcg.Rayleigh(sigma)
+			if samples[i] < 0 {
+				t.Fatalf("Rayleigh(%f) = %f; want a non-negative value", sigma, samples[i])
+			}
+		}
+
+		_, variance := meanAndVariance(samples)
+		want := (4 - math.Pi) / 2 * sigma * sigma
+		if math.Abs(variance-want)/want > 0.05 {
+			t.Errorf("Rayleigh(%f) variance = %f; want ~%f", sigma, variance, want)
+		}
+	}
+}
+
+func TestLaplaceRayleigh_InvalidParams(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Laplace b=0", func() { pcg.Laplace(0, 0) }},
+		{"Rayleigh sigma<0", func() { pcg.Rayleigh(-1) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestChiSquared(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, k := range []float64{1, 4, 10} {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.ChiSquared(k)
+		}
+
+		mean, variance := meanAndVariance(samples)
+		if math.Abs(mean-k)/k > 0.05 {
+			t.Errorf("ChiSquared(%f) mean = %f; want ~%f", k, mean, k)
+		}
+		if math.Abs(variance-2*k)/(2*k) > 0.1 {
+			t.Errorf("ChiSquared(%f) variance = %f; want ~%f", k, variance, 2*k)
+		}
+	}
+}
+
+func TestStudentT(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+
+	for _, nu := range []float64{5, 10, 30} {
+		samples := make([]float64, 200000)
+		for i := range samples {
+			samples[i] = pcg.StudentT(nu)
+		}
+
+		mean, variance := meanAndVariance(samples)
+		if math.Abs(mean) > 0.02 {
+			t.Errorf("StudentT(%f) mean = %f; want ~0", nu, mean)
+		}
+		want := nu / (nu - 2)
+		if math.Abs(variance-want)/want > 0.08 {
+			t.Errorf("StudentT(%f) variance = %f; want ~%f", nu, variance, want)
+		}
+	}
+}
+
+func TestChiSquaredStudentT_InvalidParams(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"ChiSquared k=0", func() { pcg.ChiSquared(0) }},
+		{"StudentT nu<0", func() { pcg.StudentT(-1) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestFDistribution(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		d1, d2 float64
+	}{
+		{1, 10},
+		{5, 20},
+		{10, 50},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = pcg.FDistribution(tc.d1, tc.d2)
+			if samples[i] < 0 {
+				t.Fatalf("FDistribution(%f, %f) = %f; want a non-negative value", tc.d1, tc.d2, samples[i])
+			}
+		}
+
+		mean, _ := meanAndVariance(samples)
+		want := tc.d2 / (tc.d2 - 2)
+		if math.Abs(mean-want)/want > 0.05 {
+			t.Errorf("FDistribution(%f, %f) mean = %f; want ~%f", tc.d1, tc.d2, mean, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("FDistribution(0, 1) did not panic")
+		}
+	}()
+	pcg.FDistribution(0, 1)
+}
+
+func TestPoisson(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, lambda := range []float64{0, 0.5, 3, 9.5, 10, 50, 1000} {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			k := pcg.Poisson(lambda)
+			if k < 0 {
+				t.Fatalf("Poisson(%f) = %d; want a non-negative count", lambda, k)
+			}
+			samples[i] = float64(k)
+		}
+
+		mean, variance := meanAndVariance(samples)
+		if math.Abs(mean-lambda) > 0.05*lambda+0.01 {
+			t.Errorf("Poisson(%f) mean = %f; want ~%f", lambda, mean, lambda)
+		}
+		if math.Abs(variance-lambda) > 0.08*lambda+0.01 {
+			t.Errorf("Poisson(%f) variance = %f; want ~%f", lambda, variance, lambda)
+		}
+	}
+}
+
+func TestNegativeBinomial(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+
+	tests := []struct {
+		r, prob float64
+	}{
+		{1, 0.5},
+		{2.5, 0.3},
+		{10, 0.8},
+	}
+
+	for _, tc := range tests {
+		samples := make([]float64, 100000)
+		for i := range samples {
+			samples[i] = float64(pcg.NegativeBinomial(tc.r, tc.prob))
+		}
+
+		mean, _ := meanAndVariance(samples)
+		want := tc.r * (1 - tc.prob) / tc.prob
+		if math.Abs(mean-want)/want > 0.05 {
+			t.Errorf("NegativeBinomial(%f, %f) mean = %f; want ~%f", tc.r, tc.prob, mean, want)
+		}
+	}
+}
+
+func TestPoissonNegativeBinomial_InvalidParams(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Poisson lambda<0", func() { pcg.Poisson(-1) }},
+		{"NegativeBinomial r=0", func() { pcg.NegativeBinomial(0, 0.5) }},
+		{"NegativeBinomial p=0", func() { pcg.NegativeBinomial(1, 0) }},
+		{"NegativeBinomial p=1", func() { pcg.NegativeBinomial(1, 1) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestHypergeometric(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		good, bad, draws int64
+	}{
+		{0, 10, 5},
+		{10, 0, 5},
+		{5, 5, 10},
+		{10, 20, 7},
+		{50, 50, 30},
+		{1000000, 3000000, 100000},
+	}
+
+	for _, tc := range tests {
+		kmin := max(0, tc.draws-tc.bad)
+		kmax := min(tc.draws, tc.good)
+
+		samples := make([]float64, 50000)
+		for i := range samples {
+			k := pcg.Hypergeometric(tc.good, tc.bad, tc.draws)
+			if k < kmin || k > kmax {
+				t.Fatalf("Hypergeometric(%d, %d, %d) = %d; want a value in [%d, %d]",
+					tc.good, tc.bad, tc.draws, k, kmin, kmax)
+			}
+			samples[i] = float64(k)
+		}
+
+		mean, _ := meanAndVariance(samples)
+		want := float64(tc.draws) * float64(tc.good) / float64(tc.good+tc.bad)
+		if math.Abs(mean-want) > 0.02*want+0.01 {
+			t.Errorf("Hypergeometric(%d, %d, %d) mean = %f; want ~%f", tc.good, tc.bad, tc.draws, mean, want)
+		}
+	}
+}
+
+func TestHypergeometric_InvalidParams(t *testing.T) {
+	tests := []struct {
+		good, bad, draws int64
+	}{
+		{-1, 5, 1},
+		{5, -1, 1},
+		{5, 5, -1},
+		{5, 5, 11},
+	}
+
+	for _, tc := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Hypergeometric(%d, %d, %d) did not panic", tc.good, tc.bad, tc.draws)
+				}
+			}()
+			NewPCG64(1, 2).Hypergeometric(tc.good, tc.bad, tc.draws)
+		}()
+	}
+}
+
+func TestHypergeometric_LargePopulation(t *testing.T) {
+	// good+bad overflows int64 here, which must not be mistaken for draws > good+bad
+	if got := NewPCG64(1, 2).Hypergeometric(1<<62, 1<<62, 0); got != 0 {
+		t.Errorf("Hypergeometric(1<<62, 1<<62, 0) = %d; want 0", got)
+	}
+	if got := NewPCG64(1, 2).Hypergeometric(1<<62, 1<<62, 1<<62); got < 0 || got > 1<<62 {
+		t.Errorf("Hypergeometric(1<<62, 1<<62, 1<<62) = %d; want a value in [0, 1<<62]", got)
+	}
+	if got := NewPCG64(1, 2).Hypergeometric(math.MaxInt64, 1, 1); got < 0 || got > 1 {
+		t.Errorf("Hypergeometric(MaxInt64, 1, 1) = %d; want 0 or 1", got)
+	}
+}
@@ -0,0 +1,45 @@
+package pcg
+
+import "sync/atomic"
+
+// forkGuard marks a PCG64 whose state is being updated, so a second goroutine
+// entering the generator at the same time can be caught.
+type forkGuard struct {
+	busy atomic.Bool
+}
+
+const forkPanicMsg = "pcg: PCG64 used from multiple goroutines concurrently; " +
+	"give each goroutine its own generator or wrap it in a LockedPCG64"
+
+// EnableForkDetection turns on a debug check that panics when the generator is used from
+// two goroutines at the same time, which otherwise silently corrupts and correlates the
+// streams of every caller. While enabled, Uint64 and every method built on it, NextN,
+// and the MCG draws (Uint64nWithMCG, Float64MCG) set an atomic flag around their state
+// update and panic if the flag is already set. Methods that reposition the state without
+// drawing (Seed, Skip, Advance, Retreat and the like) are not checked.
+// Detection is best effort: it only fires when the calls actually overlap.
+// It is off by default, in which case the only cost is a nil check per draw.
+func (p *PCG64) EnableForkDetection() *PCG64 {
+	if p.guard == nil {
+		p.guard = &forkGuard{}
+	}
+	return p
+}
+
+func (g *forkGuard) enter() {
+	if !g.busy.CompareAndSwap(false, true) {
+		panic(forkPanicMsg)
+	}
+}
+
+func (g *forkGuard) exit() {
+	g.busy.Store(false)
+}
+
+func (p *PCG64) guardedUint64() uint64 {
+	p.guard.enter()
+	p.consumed++
+	v := uint64(p.hi.Uint32())<<32 | uint64(p.lo.Uint32())
+	p.guard.exit()
+	return v
+}
@@ -0,0 +1,91 @@
+package pcg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPCG64_EnableForkDetection(t *testing.T) {
+	pcg := NewPCG64(42, 54).EnableForkDetection()
+	ref := NewPCG64(42, 54)
+
+	for i := 0; i < 100; i++ {
+		if got, want := pcg.Uint64(), ref.Uint64(); got != want {
+			t.Fatalf("#%d: Uint64() with fork detection = %#x; want %#x", i, got, want)
+		}
+	}
+
+	// Another goroutine enters the generator and stays inside its update
+	// while this goroutine draws from the same generator.
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pcg.guard.enter()
+		close(entered)
+		<-release
+		pcg.guard.exit()
+	}()
+	<-entered
+
+	func() {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Errorf("Uint64() during a concurrent draw did not panic")
+				return
+			}
+			if msg, _ := r.(string); !strings.Contains(msg, "multiple goroutines") {
+				t.Errorf("Uint64() panic = %v; want a message about concurrent use", r)
+			}
+		}()
+		pcg.Uint64()
+	}()
+
+	close(release)
+	<-done
+
+	// once the other goroutine has left, draws succeed again
+	pcg.Uint64()
+}
+
+func TestPCG64_EnableForkDetection_NextNAndMCG(t *testing.T) {
+	pcg := NewPCG64(42, 54).EnableForkDetection()
+	ref := NewPCG64(42, 54)
+
+	got, want := pcg.NextN(8), ref.NextN(8)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("NextN(8)[%d] with fork detection = %#x; want %#x", i, got[i], want[i])
+		}
+	}
+	if got, want := pcg.Uint64nWithMCG(), ref.Uint64nWithMCG(); got != want {
+		t.Fatalf("Uint64nWithMCG() with fork detection = %#x; want %#x", got, want)
+	}
+
+	tests := []struct {
+		name string
+		draw func()
+	}{
+		{"NextN", func() { pcg.NextN(4) }},
+		{"Uint64nWithMCG", func() { pcg.Uint64nWithMCG() }},
+		{"Float64MCG", func() { pcg.Float64MCG() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcg.guard.enter()
+			defer pcg.guard.exit()
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s() during a concurrent draw did not panic", tt.name)
+				}
+			}()
+			tt.draw()
+		})
+	}
+
+	// the guard is released after each draw, so later calls succeed
+	pcg.NextN(4)
+	pcg.Float64MCG()
+}
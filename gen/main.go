@@ -8,7 +8,7 @@ import (
 )
 
 func main() {
-	rng := pcg.NewPCG32()
+	rng := pcg.NewPCG64Auto()
 
 	file, err := os.Create("random_numbers.txt")
 	if err != nil {
@@ -21,7 +21,7 @@ func main() {
 
 	// write random numbers to the file
 	for i := 0; i < 1200; i++ {
-		number := rng.Uintn32(256) // 0 ~ 255
+		number := rng.Uint64n(256) // 0 ~ 255
 		_, err := writer.WriteString(fmt.Sprintf("%d\n", number))
 		if err != nil {
 			fmt.Println("파일 쓰기 실패:", err)
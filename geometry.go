@@ -0,0 +1,33 @@
+package pcg
+
+import "math"
+
+// UnitVector2 returns a point distributed uniformly on the unit circle.
+// It draws points uniformly in the square [-1, 1)^2, rejects those outside the unit disk
+// (or at its center), and projects the accepted point onto the circle.
+func (p *PCG64) UnitVector2() (x, y float64) {
+	for {
+		u := 2*p.Float64() - 1
+		v := 2*p.Float64() - 1
+		s := u*u + v*v
+		if s < 1 && s > 0 {
+			r := math.Sqrt(s)
+			return u / r, v / r
+		}
+	}
+}
+
+// UnitVector3 returns a point distributed uniformly on the unit sphere using Marsaglia's method
+// ("Choosing a Point from the Surface of a Sphere", 1972): a point (u, v) drawn uniformly in the
+// unit disk by rejection maps to (2u*sqrt(1-s), 2v*sqrt(1-s), 1-2s) with s = u^2 + v^2.
+func (p *PCG64) UnitVector3() (x, y, z float64) {
+	for {
+		u := 2*p.Float64() - 1
+		v := 2*p.Float64() - 1
+		s := u*u + v*v
+		if s < 1 {
+			f := 2 * math.Sqrt(1-s)
+			return u * f, v * f, 1 - 2*s
+		}
+	}
+}
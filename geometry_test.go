@@ -0,0 +1,54 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPCG64_UnitVector2(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	const n = 100000
+	var sx, sy float64
+	for i := 0; i < n; i++ {
+		x, y := pcg.UnitVector2()
+		if norm := math.Hypot(x, y); math.Abs(norm-1) > 1e-12 {
+			t.Fatalf("UnitVector2() = (%f, %f) with norm %f; want norm 1", x, y, norm)
+		}
+		sx += x
+		sy += y
+	}
+
+	for _, m := range []float64{sx / n, sy / n} {
+		if math.Abs(m) > 0.02 {
+			t.Errorf("UnitVector2() coordinate mean = %f; want ~0", m)
+		}
+	}
+}
+
+func TestPCG64_UnitVector3(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	const n = 100000
+	var sum, sumSq [3]float64
+	for i := 0; i < n; i++ {
+		x, y, z := pcg.UnitVector3()
+		if norm := math.Sqrt(x*x + y*y + z*z); math.Abs(norm-1) > 1e-12 {
+			t.Fatalf("UnitVector3() = (%f, %f, %f) with norm %f; want norm 1", x, y, z, norm)
+		}
+		for k, c := range [3]float64{x, y, z} {
+			sum[k] += c
+			sumSq[k] += c * c
+		}
+	}
+
+	// on the uniform sphere each coordinate has mean 0 and variance 1/3
+	for k := range sum {
+		if m := sum[k] / n; math.Abs(m) > 0.02 {
+			t.Errorf("UnitVector3() coordinate %d mean = %f; want ~0", k, m)
+		}
+		if v := sumSq[k] / n; math.Abs(v-1.0/3) > 0.02 {
+			t.Errorf("UnitVector3() coordinate %d variance = %f; want ~1/3", k, v)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package pcg
+
+import "math"
+
+// RandomEdges samples an Erdős–Rényi G(n, prob) graph: it considers each of the n*(n-1)/2
+// potential undirected edges between vertices [0, n) and calls visit(u, v), with u < v, for
+// each edge included with probability prob. Edges are visited in order of increasing v, then u.
+//
+// Rather than drawing once per pair, it draws the geometrically distributed gap to the next
+// included edge (Batagelj and Brandes, "Efficient generation of large random networks"),
+// so the cost is proportional to the number of edges produced instead of n^2.
+// It panics if n < 0 or prob is not in [0, 1].
+func (p *PCG64) RandomEdges(n int, prob float64, visit func(u, v int)) {
+	if n < 0 || !(prob >= 0 && prob <= 1) {
+		panic("invalid argument to RandomEdges")
+	}
+	if prob == 0 {
+		return
+	}
+	if prob == 1 {
+		for v := 1; v < n; v++ {
+			for u := 0; u < v; u++ {
+				visit(u, v)
+			}
+		}
+		return
+	}
+
+	logq := math.Log1p(-prob)
+	v, w := 1, -1
+	for v < n {
+		// gap ~ Geometric(prob): the number of pairs skipped before the next edge
+		gap := math.Floor(math.Log1p(-p.Float64()) / logq)
+		if gap >= float64(n)*float64(n) { // past the last pair; also avoids int overflow
+			return
+		}
+		w += 1 + int(gap)
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			visit(w, v)
+		}
+	}
+}
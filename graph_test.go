@@ -0,0 +1,51 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPCG64_RandomEdges(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, tc := range []struct {
+		n    int
+		prob float64
+	}{
+		{0, 0.5},
+		{1, 0.5},
+		{10, 1},
+		{10, 0},
+		{200, 0.5},
+		{2000, 0.001},
+		{500, 0.1},
+	} {
+		pairs := tc.n * (tc.n - 1) / 2
+		seen := make(map[[2]int]bool)
+		lastU, lastV := -1, 0
+		pcg.RandomEdges(tc.n, tc.prob, func(u, v int) {
+			if u < 0 || u >= v || v >= tc.n {
+				t.Fatalf("RandomEdges(%d, %v) visited (%d, %d); want 0 <= u < v < n", tc.n, tc.prob, u, v)
+			}
+			if v < lastV || (v == lastV && u <= lastU) {
+				t.Fatalf("RandomEdges(%d, %v) visited (%d, %d) after (%d, %d); want increasing order", tc.n, tc.prob, u, v, lastU, lastV)
+			}
+			lastU, lastV = u, v
+			seen[[2]int{u, v}] = true
+		})
+
+		want := tc.prob * float64(pairs)
+		got := float64(len(seen))
+		// allow 5 standard deviations of the binomial edge count, plus a little slack
+		if tol := 5*math.Sqrt(want*(1-tc.prob)) + 1; math.Abs(got-want) > tol {
+			t.Errorf("RandomEdges(%d, %v) produced %d edges; want ~%.0f", tc.n, tc.prob, len(seen), want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("RandomEdges(10, 1.5) did not panic")
+		}
+	}()
+	pcg.RandomEdges(10, 1.5, func(u, v int) {})
+}
@@ -0,0 +1,52 @@
+package pcg
+
+import "math/bits"
+
+// Histogram counts samples into bins equal-width bins spanning [min, max).
+// Samples outside the range (including NaN) are not counted.
+// It panics if bins <= 0 or max <= min.
+func Histogram(samples []float64, bins int, min, max float64) []int {
+	if bins <= 0 || !(max > min) {
+		panic("invalid argument to Histogram")
+	}
+
+	counts := make([]int, bins)
+	width := (max - min) / float64(bins)
+	for _, s := range samples {
+		if !(s >= min && s < max) {
+			continue
+		}
+		idx := int((s - min) / width)
+		if idx >= bins { // guard against rounding right below max
+			idx = bins - 1
+		}
+		counts[idx]++
+	}
+	return counts
+}
+
+// HistogramUint64 counts samples into bins equal-width bins spanning the closed range [min, max],
+// so HistogramUint64(samples, bins, 0, math.MaxUint64) covers every uint64.
+// Samples outside the range are not counted.
+// It panics if bins <= 0 or max < min.
+func HistogramUint64(samples []uint64, bins int, min, max uint64) []int {
+	if bins <= 0 || max < min {
+		panic("invalid argument to HistogramUint64")
+	}
+
+	counts := make([]int, bins)
+	span := max - min + 1 // 0 means the full 2^64 range
+	for _, s := range samples {
+		if s < min || s > max {
+			continue
+		}
+		// idx = (s-min) * bins / span, computed in 128 bits
+		hi, lo := bits.Mul64(s-min, uint64(bins))
+		idx := hi
+		if span != 0 {
+			idx, _ = bits.Div64(hi, lo, span)
+		}
+		counts[idx]++
+	}
+	return counts
+}
@@ -0,0 +1,87 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogram_Uniform(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	const (
+		n    = 100000
+		bins = 10
+	)
+
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = pcg.Float64Full()
+	}
+
+	counts := Histogram(samples, bins, 0, 1)
+	total := 0
+	for i, c := range counts {
+		total += c
+		if want := n / bins; abs(c-want) > want/10 {
+			t.Errorf("bin %d count = %d; want ~%d", i, c, want)
+		}
+	}
+	if total != n {
+		t.Errorf("Histogram() total = %d; want %d", total, n)
+	}
+}
+
+func TestHistogram_Edges(t *testing.T) {
+	samples := []float64{-1, 0, 0.5, 0.99, 1, 2, math.NaN()}
+	got := Histogram(samples, 2, 0, 1)
+	want := []int{1, 2}
+	if !isArrayEqual(got, want) {
+		t.Errorf("Histogram(%v) = %v; want %v", samples, got, want)
+	}
+}
+
+func TestHistogramUint64(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	const (
+		n    = 100000
+		bins = 8
+	)
+
+	samples := make([]uint64, n)
+	for i := range samples {
+		samples[i] = pcg.Uint64()
+	}
+
+	counts := HistogramUint64(samples, bins, 0, math.MaxUint64)
+	for i, c := range counts {
+		if want := n / bins; abs(c-want) > want/10 {
+			t.Errorf("bin %d count = %d; want ~%d", i, c, want)
+		}
+	}
+
+	got := HistogramUint64([]uint64{0, 5, 9, 10, 11, 20}, 2, 0, 9)
+	if want := []int{1, 2}; !isArrayEqual(got, want) {
+		t.Errorf("HistogramUint64() = %v; want %v", got, want)
+	}
+}
+
+func TestHistogram_InvalidArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Histogram bins=0", func() { Histogram(nil, 0, 0, 1) }},
+		{"Histogram max<=min", func() { Histogram(nil, 1, 1, 1) }},
+		{"HistogramUint64 max<min", func() { HistogramUint64(nil, 1, 2, 1) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package pcg
+
+import "sync"
+
+// LockedPCG64 wraps a PCG64 with a mutex so it can be shared between goroutines.
+// A plain PCG64 is not safe for concurrent use: interleaved state updates corrupt the output
+// of every caller. Each LockedPCG64 method holds the lock for the whole call, so the values
+// handed out across goroutines are exactly the values of the underlying single stream.
+type LockedPCG64 struct {
+	mu sync.Mutex
+	p  *PCG64
+}
+
+// NewLockedPCG64 returns a LockedPCG64 guarding p. p must not be used directly afterwards.
+func NewLockedPCG64(p *PCG64) *LockedPCG64 {
+	return &LockedPCG64{p: p}
+}
+
+// Seed reseeds the underlying generator. See PCG64.Seed.
+func (l *LockedPCG64) Seed(seed1, seed2, seq1, seq2 uint64) {
+	l.mu.Lock()
+	l.p.Seed(seed1, seed2, seq1, seq2)
+	l.mu.Unlock()
+}
+
+// Uint64 returns a pseudorandom 64-bit unsigned integer. See PCG64.Uint64.
+func (l *LockedPCG64) Uint64() uint64 {
+	l.mu.Lock()
+	v := l.p.Uint64()
+	l.mu.Unlock()
+	return v
+}
+
+// Uint64n returns a pseudorandom number in the range [0, bound). See PCG64.Uint64n.
+func (l *LockedPCG64) Uint64n(bound uint64) uint64 {
+	l.mu.Lock()
+	v := l.p.Uint64n(bound)
+	l.mu.Unlock()
+	return v
+}
+
+// Float64 returns a random float64 in the range [0.0, 1.0). See PCG64.Float64.
+func (l *LockedPCG64) Float64() float64 {
+	l.mu.Lock()
+	v := l.p.Float64()
+	l.mu.Unlock()
+	return v
+}
+
+// Read fills buf with random bytes. See PCG64.Read.
+// The whole buffer is filled under the lock, so concurrent readers never see overlapping bytes.
+func (l *LockedPCG64) Read(buf []byte) (int, error) {
+	l.mu.Lock()
+	n, err := l.p.Read(buf)
+	l.mu.Unlock()
+	return n, err
+}
@@ -0,0 +1,74 @@
+package pcg
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+)
+
+func TestLockedPCG64_ConcurrentRead(t *testing.T) {
+	const (
+		workers = 8
+		reads   = 1000
+	)
+
+	locked := NewLockedPCG64(NewPCG64(42, 54))
+
+	var wg sync.WaitGroup
+	results := make([][]uint64, workers)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			buf := make([]byte, 16)
+			for i := 0; i < reads; i++ {
+				if _, err := locked.Read(buf); err != nil {
+					t.Errorf("Read() error = %v; want nil", err)
+					return
+				}
+				results[w] = append(results[w],
+					binary.LittleEndian.Uint64(buf[0:]),
+					binary.LittleEndian.Uint64(buf[8:]))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// Every 16-byte read consumes exactly two draws under the lock, so together the
+	// workers must have received exactly the first workers*reads*2 values of the stream.
+	want := make(map[uint64]int)
+	ref := NewPCG64(42, 54)
+	for i := 0; i < workers*reads*2; i++ {
+		want[ref.Uint64()]++
+	}
+	for w, res := range results {
+		for _, v := range res {
+			if want[v] == 0 {
+				t.Fatalf("worker %d got %#x, which is duplicated or not part of the stream", w, v)
+			}
+			want[v]--
+		}
+	}
+}
+
+func TestLockedPCG64_Concurrent(t *testing.T) {
+	locked := NewLockedPCG64(NewPCG64(1, 2))
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				locked.Uint64()
+				if v := locked.Uint64n(10); v >= 10 {
+					t.Errorf("Uint64n(10) = %d; want a value in [0, 10)", v)
+				}
+				if v := locked.Float64(); v < 0 || v >= 1 {
+					t.Errorf("Float64() = %f; want a value in [0, 1)", v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
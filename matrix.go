@@ -0,0 +1,20 @@
+package pcg
+
+// FillMatrix returns a newly allocated rows×cols matrix of Float64 values, drawn in row-major
+// order, so it matches rows*cols sequential calls to Float64. The rows share one backing array.
+// It panics if rows < 0 or cols < 0.
+func (p *PCG64) FillMatrix(rows, cols int) [][]float64 {
+	if rows < 0 || cols < 0 {
+		panic("invalid argument to FillMatrix")
+	}
+
+	backing := make([]float64, rows*cols)
+	for i := range backing {
+		backing[i] = p.Float64()
+	}
+	m := make([][]float64, rows)
+	for r := range m {
+		m[r] = backing[r*cols : (r+1)*cols : (r+1)*cols]
+	}
+	return m
+}
@@ -0,0 +1,48 @@
+package pcg
+
+import "testing"
+
+func TestPCG64_FillMatrix(t *testing.T) {
+	const rows, cols = 3, 5
+	m := NewPCG64(42, 54).FillMatrix(rows, cols)
+	if len(m) != rows {
+		t.Fatalf("FillMatrix(%d, %d) has %d rows; want %d", rows, cols, len(m), rows)
+	}
+
+	seq := NewPCG64(42, 54)
+	for r, row := range m {
+		if len(row) != cols || cap(row) != cols {
+			t.Fatalf("FillMatrix row %d len = %d, cap = %d; want %d", r, len(row), cap(row), cols)
+		}
+		for c, v := range row {
+			if want := seq.Float64(); v != want {
+				t.Errorf("FillMatrix[%d][%d] = %v; want %v", r, c, v, want)
+			}
+		}
+	}
+
+	if m := NewPCG64(1, 2).FillMatrix(4, 0); len(m) != 4 || len(m[0]) != 0 {
+		t.Errorf("FillMatrix(4, 0) = %v; want 4 empty rows", m)
+	}
+
+	for _, dims := range [][2]int{{-1, 1}, {1, -1}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("FillMatrix(%d, %d) did not panic", dims[0], dims[1])
+				}
+			}()
+			NewPCG64(1, 2).FillMatrix(dims[0], dims[1])
+		}()
+	}
+}
+
+var sinkMatrix [][]float64
+
+func BenchmarkPCG64_FillMatrix(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkMatrix = pcg.FillMatrix(64, 64)
+	}
+}
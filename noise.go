@@ -0,0 +1,18 @@
+package pcg
+
+import "math"
+
+// Gradient2D returns a unit gradient vector for the integer lattice point (x, y), the core
+// primitive of Perlin-style gradient noise. The coordinates are hashed together with the
+// generator's current state using the SplitMix64 finalizer, and the hash picks an angle
+// uniformly in [0, 2π). The generator is not advanced, so the same (x, y) always yields the
+// same gradient until the generator is drawn from or reseeded; seed a dedicated generator
+// to use as the noise seed.
+func (p *PCG64) Gradient2D(x, y int) (gx, gy float64) {
+	h := p.Fingerprint()
+	for _, w := range [2]uint64{uint64(x), uint64(y)} {
+		z := h ^ w
+		h = splitMix64(&z)
+	}
+	return math.Sincos(2 * math.Pi * float64(h>>11) * inv53)
+}
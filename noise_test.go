@@ -0,0 +1,46 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPCG64_Gradient2D(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	state := pcg.Fingerprint()
+
+	const size, bins = 100, 8
+	counts := make([]int, bins)
+	for x := -size / 2; x < size/2; x++ {
+		for y := -size / 2; y < size/2; y++ {
+			gx, gy := pcg.Gradient2D(x, y)
+			if r := math.Hypot(gx, gy); math.Abs(r-1) > 1e-12 {
+				t.Fatalf("Gradient2D(%d, %d) = (%v, %v) has length %v; want 1", x, y, gx, gy, r)
+			}
+			if hx, hy := pcg.Gradient2D(x, y); hx != gx || hy != gy {
+				t.Fatalf("Gradient2D(%d, %d) = (%v, %v) then (%v, %v); want the same gradient", x, y, gx, gy, hx, hy)
+			}
+			angle := math.Atan2(gy, gx) + math.Pi
+			counts[int(angle/(2*math.Pi)*bins)%bins]++
+		}
+	}
+	if pcg.Fingerprint() != state {
+		t.Errorf("Gradient2D advanced the generator")
+	}
+
+	// the angles should be spread evenly around the circle
+	for i, c := range counts {
+		if want := size * size / bins; math.Abs(float64(c-want)) > 0.1*float64(want) {
+			t.Errorf("angle bin %d holds %d gradients; want ~%d", i, c, want)
+		}
+	}
+
+	// a generator with the same seed gives the same field, another seed a different one
+	gx, gy := NewPCG64(42, 54).Gradient2D(3, -7)
+	if hx, hy := pcg.Gradient2D(3, -7); hx != gx || hy != gy {
+		t.Errorf("Gradient2D(3, -7) = (%v, %v); want (%v, %v) from an identically seeded generator", hx, hy, gx, gy)
+	}
+	if hx, hy := NewPCG64(1, 2).Gradient2D(3, -7); hx == gx && hy == gy {
+		t.Errorf("Gradient2D(3, -7) is the same for different seeds")
+	}
+}
@@ -0,0 +1,59 @@
+package pcg
+
+import "encoding/binary"
+
+// An OutputFunc maps the pre-advance LCG state to a 32-bit output, the "permutation" step
+// that distinguishes the PCG variants (XSH-RR, XSH-RS, RXS-M and so on).
+type OutputFunc func(state uint64) uint32
+
+// CustomPCG32 draws from the stream of a PCG32 but maps each state through an OutputFunc
+// instead of the XSH-RR permutation, so alternative PCG variants can be tried without forking
+// the package. Keeping the function out of PCG32 leaves PCG32.Uint32 free of the extra check.
+type CustomPCG32 struct {
+	p      *PCG32
+	output OutputFunc
+}
+
+// WithOutput returns a CustomPCG32 that applies fn to the states of p; nil selects the default
+// XSH-RR. The two share one state: drawing from either advances both, and Seed, Advance and the
+// other state methods of p apply to the CustomPCG32 too.
+// The function is not part of the generator state: State and any serialized form capture only
+// the state and increment of p, so the function must be set again after restoring.
+func (p *PCG32) WithOutput(fn OutputFunc) *CustomPCG32 {
+	if fn == nil {
+		fn = permute
+	}
+	return &CustomPCG32{p: p, output: fn}
+}
+
+// Uint32 advances the underlying PCG32 by one step and returns the output function
+// applied to the old state.
+func (c *CustomPCG32) Uint32() uint32 {
+	old := c.p.state
+	c.p.state = old*multiplier + c.p.increment
+	return c.output(old)
+}
+
+// Uint64 returns two Uint32 draws concatenated, the first forming the upper half, like
+// PCG32.Uint64.
+func (c *CustomPCG32) Uint64() uint64 {
+	upper := uint64(c.Uint32())
+	return upper<<32 | uint64(c.Uint32())
+}
+
+// Read fills buf with the little-endian bytes of successive Uint32 draws, like PCG32.Read.
+// It always returns len(buf) and a nil error.
+func (c *CustomPCG32) Read(buf []byte) (int, error) {
+	n := len(buf)
+	i := 0
+	for ; i <= n-4; i += 4 {
+		binary.LittleEndian.PutUint32(buf[i:], c.Uint32())
+	}
+	if i < n {
+		val := c.Uint32()
+		for k := 0; i+k < n; k++ {
+			buf[i+k] = byte(val >> (8 * k))
+		}
+	}
+	return n, nil
+}
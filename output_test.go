@@ -0,0 +1,55 @@
+package pcg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPCG32_WithOutput(t *testing.T) {
+	// the low half of the state is a trivially recognizable output
+	var calls int
+	low := func(state uint64) uint32 {
+		calls++
+		return uint32(state)
+	}
+
+	p := NewPCG32().Seed(42, 54)
+	c := p.WithOutput(low)
+	ref := NewPCG32().Seed(42, 54)
+	for i := 0; i < 10; i++ {
+		want := uint32(ref.State())
+		ref.Uint32()
+		if got := c.Uint32(); got != want {
+			t.Errorf("#%d: Uint32() = %d; want %d from the custom output", i, got, want)
+		}
+	}
+	if calls != 10 {
+		t.Errorf("output function called %d times; want 10", calls)
+	}
+	if p.State() != ref.State() {
+		t.Errorf("PCG32 state after 10 custom draws = %#x; want %#x", p.State(), ref.State())
+	}
+
+	buf := make([]byte, 30)
+	c.Read(buf)
+	if calls != 18 {
+		t.Errorf("output function called %d times after Read(30 bytes); want 18", calls)
+	}
+
+	// nil selects XSH-RR
+	want := NewPCG32().Seed(42, 54).Uint64()
+	if got := NewPCG32().Seed(42, 54).WithOutput(nil).Uint64(); got != want {
+		t.Errorf("WithOutput(nil).Uint64() = %#x; want %#x", got, want)
+	}
+}
+
+func TestCustomPCG32_Read(t *testing.T) {
+	for _, n := range []int{0, 3, 8, 1027} {
+		a, b := make([]byte, n), make([]byte, n)
+		NewPCG32().Seed(42, 54).Read(a)
+		NewPCG32().Seed(42, 54).WithOutput(permute).Read(b)
+		if !bytes.Equal(a, b) {
+			t.Errorf("WithOutput(permute).Read(%d bytes) = %x; want PCG32.Read bytes %x", n, b, a)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package pcg
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"math/big"
+)
 
 // ref: https://gist.github.com/ivan-pi/060e38d5f9a86c57923a61fbf18d095c
 const (
@@ -9,6 +12,15 @@ const (
 	incrementStep = 0x9e3779b97f4a7c15 //  https://www.pcg-random.org/posts/bugs-in-splitmix.html
 )
 
+// The raw PCG32 constants, exported so that compatible implementations can check they match.
+// Multiplier is the LCG multiplier; DefaultState and IncrementStep are the state and increment
+// of NewPCG32, and Seed also adds IncrementStep when mixing in the seed state.
+const (
+	Multiplier    uint64 = multiplier
+	IncrementStep uint64 = incrementStep
+	DefaultState  uint64 = defaultState
+)
+
 // PCG32 is a 32-bit pseudorandom number generator based on the PCG family of algorithms.
 type PCG32 struct {
 	state, increment uint64
@@ -29,6 +41,24 @@ func (p *PCG32) Seed(state, sequence uint64) *PCG32 {
 	return p
 }
 
+// SeedChecked seeds the generator like Seed and documents the invariant it relies on.
+// The LCG only reaches its full period of 2^64 when the increment is odd; Seed guarantees
+// this by deriving the increment as (sequence << 1) | 1, so every input, including all zeros,
+// is valid and there is nothing to reject.
+func (p *PCG32) SeedChecked(state, sequence uint64) *PCG32 {
+	return p.Seed(state, sequence)
+}
+
+// State returns the current internal LCG state of the generator without advancing it.
+func (p *PCG32) State() uint64 {
+	return p.state
+}
+
+// Increment returns the LCG increment (stream selector) of the generator.
+func (p *PCG32) Increment() uint64 {
+	return p.increment
+}
+
 // neg_mask is a mask to extract the lower 5 bits of a number.
 const neg_mask = 31
 
@@ -54,6 +84,14 @@ func (p *PCG32) Uint32() uint32 {
 	return (xorshifted >> rot) | (xorshifted << (neg_mask - rot))
 }
 
+// permute applies the output permutation of Uint32 to an LCG state.
+func permute(old uint64) uint32 {
+	xorshifted := uint32(((old >> 18) ^ old) >> 27)
+	rot := uint32(old >> 59)
+
+	return (xorshifted >> rot) | (xorshifted << (neg_mask - rot))
+}
+
 // Uintn32 generates a pseudorandom number in the range [0, bound) using the PCG32 algorithm.
 func (p *PCG32) Uintn32(bound uint32) uint32 {
 	if bound == 0 {
@@ -62,19 +100,74 @@ func (p *PCG32) Uintn32(bound uint32) uint32 {
 
 	threshold := -bound % bound
 	for {
-		r := p.Uint32()
-		if r >= threshold {
+		if r := p.Uint32(); r >= threshold {
 			return r % bound
 		}
 	}
 }
 
+// FillUintn fills dst with pseudorandom numbers in the range [0, bound).
+// It produces the same values as calling Uintn32 for each element, but computes the
+// rejection threshold only once for the whole slice. A bound of 0 fills dst with zeros.
+func (p *PCG32) FillUintn(dst []uint32, bound uint32) {
+	if bound == 0 {
+		for i := range dst {
+			dst[i] = 0
+		}
+		return
+	}
+
+	threshold := -bound % bound
+	for i := range dst {
+		r := p.Uint32()
+		for r < threshold {
+			r = p.Uint32()
+		}
+		dst[i] = r % bound
+	}
+}
+
 // Uint63 generates a pseudorandom 63-bit integer using two 32-bit numbers.
 // The function ensures that the returned number is within the range of 0 to 2^63-1.
 func (p *PCG32) Uint63() int64 {
-	upper := int64(p.Uint32()) & 0x7FFFFFFF // Use only the lower 31 bits of the upper half
-	lower := int64(p.Uint32())              // Use all 32 bits of the lower half
-	return (upper << 32) | lower            // Combine the two halves to form a 63-bit integer
+	return int64(p.Uint64() & (1<<63 - 1)) // the top bit of the upper half is dropped
+}
+
+// Uint64 generates a pseudorandom 64-bit integer by concatenating two Uint32 draws,
+// the first forming the upper half. Its statistical quality is that of two consecutive
+// PCG32 outputs; it is not a 64-bit generator in its own right.
+func (p *PCG32) Uint64() uint64 {
+	upper := uint64(p.Uint32())
+	return upper<<32 | uint64(p.Uint32())
+}
+
+// Uint64n generates a pseudorandom number in the range [0, bound) from Uint64 draws,
+// rejecting draws below -bound % bound so the result is unbiased. Like Uintn32,
+// a bound of 0 returns 0.
+func (p *PCG32) Uint64n(bound uint64) uint64 {
+	if bound == 0 {
+		return 0
+	}
+
+	threshold := -bound % bound
+	for {
+		r := p.Uint64()
+		if r >= threshold {
+			return r % bound
+		}
+	}
+}
+
+// Float64 returns a random float64 in the range [0.0, 1.0) built from the top 53 bits of
+// Uint64, i.e. of two Uint32 draws, the full precision of a float64 mantissa.
+func (p *PCG32) Float64() float64 {
+	return float64(p.Uint64()>>11) * inv53
+}
+
+// Float32 returns a random float32 in the range [0.0, 1.0) built from the top 24 bits
+// of a single Uint32 draw, the full precision of a float32 mantissa.
+func (p *PCG32) Float32() float32 {
+	return float32(p.Uint32()>>8) * (1.0 / (1 << 24))
 }
 
 // advancedLCG64 is an implementation of a 64-bit linear congruential generator (LCG).
@@ -125,10 +218,26 @@ func (p *PCG32) advancedLCG64(state, delta, mul, add uint64) uint64 {
 }
 
 // Advance moves the PCG32 generator forward by `delta` steps.
-// It updates the internal state of the generator using the `lcg64` function
-// and returns the updated PCG32 instance.
+// It updates the internal state of the generator using the `lcg64` function with the
+// generator's own increment, so it lands exactly where `delta` Uint32 calls would,
+// and returns the updated PCG32 instance. Advance(0) leaves the state untouched.
 func (p *PCG32) Advance(delta uint64) *PCG32 {
-	p.state = p.advancedLCG64(p.state, delta, multiplier, incrementStep)
+	if delta == 0 {
+		return p
+	}
+	p.state = p.advancedLCG64(p.state, delta, multiplier, p.increment)
+	return p
+}
+
+// Skip discards the next n outputs by stepping the LCG n times without computing the output
+// permutation. It leaves the generator in exactly the same state as n discarded Uint32 calls.
+// Unlike Advance it costs O(n); use it to consume draws, and Advance to jump.
+func (p *PCG32) Skip(n uint64) *PCG32 {
+	state := p.state
+	for i := uint64(0); i < n; i++ {
+		state = state*multiplier + p.increment
+	}
+	p.state = state
 	return p
 }
 
@@ -141,6 +250,55 @@ func (p *PCG32) Retreat(delta uint64) *PCG32 {
 	return p.Advance(safeDelta)
 }
 
+// Jump moves the generator by delta steps: forward with Advance for positive delta and
+// backward with Retreat for negative delta, so Jump(n) followed by Jump(-n) is a no-op.
+func (p *PCG32) Jump(delta int64) *PCG32 {
+	if delta < 0 {
+		return p.Retreat(uint64(-delta))
+	}
+	return p.Advance(uint64(delta))
+}
+
+// Period returns the period of the generator, 2^64: with an odd increment the LCG visits
+// every 64-bit state once before repeating, and each state yields one Uint32.
+func (p *PCG32) Period() *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), 64)
+}
+
+// Clone returns an independent copy of the generator. The copy continues the same stream
+// from the same position, and advancing either one does not affect the other.
+func (p *PCG32) Clone() *PCG32 {
+	c := *p
+	return &c
+}
+
+// Distance returns the number of steps p must advance to reach the state of other,
+// that is, the d for which p.Clone().Advance(d) has the same state as other.
+// It is the discrete logarithm of the LCG, computed one bit at a time in O(64) steps,
+// and is useful for checking that partitioned substreams do not overlap.
+// It panics if p and other are on different streams (different increments), or if the
+// increment is even, as only a raw state passed to NewPCG64FromState can make it: the LCG then
+// does not visit every state, so the distance may not exist.
+func (p *PCG32) Distance(other *PCG32) uint64 {
+	if p.increment != other.increment || p.increment&1 == 0 {
+		panic("invalid argument to Distance")
+	}
+
+	state, target := p.state, other.state
+	mul, add := uint64(multiplier), p.increment
+	distance := uint64(0)
+	// with an odd increment each bit settles in turn, so 64 rounds always suffice
+	for bit := uint64(1); bit != 0 && state != target; bit <<= 1 {
+		if state&bit != target&bit {
+			state = state*mul + add
+			distance |= bit
+		}
+		add = (mul + 1) * add
+		mul *= mul
+	}
+	return distance
+}
+
 func (p *PCG32) Shuffle(n int, swap func(i, j int)) {
 	if n < 0 {
 		panic("invalid argument to shuffle")
@@ -180,6 +338,8 @@ func (p *PCG32) Perm(n int) []int {
 // Return values:
 //   - n: The number of bytes generated and stored in the byte slice. It is always equal to len(buf).
 //   - err: Always returns nil, indicating no error occurred.
+//
+// A nil or empty buf returns (0, nil) without drawing from the generator.
 func (p *PCG32) Read(buf []byte) (int, error) {
 	n := len(buf)
 	i := 0
@@ -208,3 +368,49 @@ func (p *PCG32) Read(buf []byte) (int, error) {
 
 	return n, nil
 }
+
+// readFastLanes is the number of interleaved streams used by ReadFast.
+const readFastLanes = 4
+
+// ReadFast fills buf with random bytes like Read, but interleaves four independent
+// PCG32 streams so the LCG updates no longer form a single serial dependency chain.
+//
+// The first stream is the generator itself; the other three are seeded from its output
+// and use distinct increments, so they walk different sequences. The output is deterministic
+// for a given generator state but differs from Read, which stays the reproducible single-stream path.
+// Buffers shorter than 16 bytes are delegated to Read.
+func (p *PCG32) ReadFast(buf []byte) (int, error) {
+	n := len(buf)
+	if n < 4*readFastLanes {
+		return p.Read(buf)
+	}
+
+	s1, inc1 := uint64(p.Uint32())<<32|uint64(p.Uint32()), p.increment^1<<1 // increments stay odd
+	s2, inc2 := uint64(p.Uint32())<<32|uint64(p.Uint32()), p.increment^2<<1
+	s3, inc3 := uint64(p.Uint32())<<32|uint64(p.Uint32()), p.increment^3<<1
+	s0, inc0 := p.state, p.increment
+
+	i := 0
+	for ; i <= n-4*readFastLanes; i += 4 * readFastLanes {
+		b := buf[i : i+16 : i+16]
+		binary.LittleEndian.PutUint32(b[0:], permute(s0))
+		binary.LittleEndian.PutUint32(b[4:], permute(s1))
+		binary.LittleEndian.PutUint32(b[8:], permute(s2))
+		binary.LittleEndian.PutUint32(b[12:], permute(s3))
+		s0 = s0*multiplier + inc0
+		s1 = s1*multiplier + inc1
+		s2 = s2*multiplier + inc2
+		s3 = s3*multiplier + inc3
+	}
+	p.state = s0
+
+	// handle remaining bytes (less than 16 bytes) with the generator's own stream
+	for ; i < n; i += 4 {
+		val := p.Uint32()
+		for k := 0; k < 4 && i+k < n; k++ {
+			buf[i+k] = byte(val >> (8 * k))
+		}
+	}
+
+	return n, nil
+}
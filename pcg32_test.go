@@ -1,9 +1,11 @@
 package pcg
 
 import (
+	"encoding/binary"
 	"math"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -33,6 +35,62 @@ func TestPCG32_Uintn32(t *testing.T) {
 	}
 }
 
+func TestPCG32_StateAndIncrement(t *testing.T) {
+	pcg := NewPCG32().Seed(12345, 67890)
+	state, increment := pcg.State(), pcg.Increment()
+
+	if state != pcg.state || increment != pcg.increment {
+		t.Errorf("State(), Increment() = %d, %d; want %d, %d", state, increment, pcg.state, pcg.increment)
+	}
+	if pcg.State() != state || pcg.Increment() != increment {
+		t.Errorf("State() and Increment() must not advance the generator")
+	}
+
+	want := NewPCG32().Seed(12345, 67890).Uint32()
+	if got := pcg.Uint32(); got != want {
+		t.Errorf("Uint32() after State() = %d; want %d", got, want)
+	}
+}
+
+func TestPCG32_FillUintn(t *testing.T) {
+	for _, bound := range []uint32{0, 1, 6, 100, 1<<31 + 1} {
+		filled := NewPCG32().Seed(12345, 67890)
+		single := NewPCG32().Seed(12345, 67890)
+
+		dst := make([]uint32, 1000)
+		for i := range dst {
+			dst[i] = 7
+		}
+		filled.FillUintn(dst, bound)
+
+		for i, got := range dst {
+			if want := single.Uintn32(bound); got != want {
+				t.Fatalf("FillUintn(%d)[%d] = %d; want %d", bound, i, got, want)
+			}
+		}
+	}
+}
+
+func TestPCG32_SeedChecked(t *testing.T) {
+	edge := []uint64{0, 1, 2, math.MaxUint64, math.MaxUint64 - 1, 1 << 63}
+	for _, state := range edge {
+		for _, seq := range edge {
+			if p := NewPCG32().SeedChecked(state, seq); p.increment&1 != 1 {
+				t.Errorf("SeedChecked(%d, %d) increment = %d; want an odd value", state, seq, p.increment)
+			}
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	pcg := NewPCG32()
+	for i := 0; i < 10000; i++ {
+		pcg.Seed(r.Uint64(), r.Uint64())
+		if pcg.increment&1 != 1 {
+			t.Fatalf("Seed() increment = %d; want an odd value", pcg.increment)
+		}
+	}
+}
+
 func TestUint63PCG64(t *testing.T) {
 	pcg := NewPCG64(42, 54)
 	pcg.Seed(42, 54, 18, 27)
@@ -44,6 +102,76 @@ func TestUint63PCG64(t *testing.T) {
 	}
 }
 
+func TestPCG32_Uint64(t *testing.T) {
+	pcg := NewPCG32().Seed(12345, 67890)
+	ref := NewPCG32().Seed(12345, 67890)
+
+	for i := 0; i < 1000; i++ {
+		want := uint64(ref.Uint32())<<32 | uint64(ref.Uint32())
+		if got := pcg.Uint64(); got != want {
+			t.Fatalf("#%d: Uint64() = %#x; want %#x", i, got, want)
+		}
+	}
+}
+
+func TestPCG32_Uint64n(t *testing.T) {
+	a := NewPCG32().Seed(42, 54)
+	b := NewPCG32().Seed(42, 54)
+
+	for _, bound := range []uint64{1, 10, 1 << 32, 1<<32 + 1, 1<<63 + 1, math.MaxUint64} {
+		for i := 0; i < 1000; i++ {
+			v := a.Uint64n(bound)
+			if v >= bound {
+				t.Fatalf("Uint64n(%d) = %d; want a value in [0, %d)", bound, v, bound)
+			}
+			if w := b.Uint64n(bound); v != w {
+				t.Fatalf("Uint64n(%d) #%d = %d, %d for identically seeded generators", bound, i, v, w)
+			}
+		}
+	}
+
+	if v := a.Uint64n(0); v != 0 {
+		t.Errorf("Uint64n(0) = %d; want 0", v)
+	}
+}
+
+func TestPCG32_Float64(t *testing.T) {
+	pcg := NewPCG32().Seed(42, 54)
+	sum := 0.0
+	const n = 100000
+	for i := 0; i < n; i++ {
+		val := pcg.Float64()
+		if val < 0.0 || val >= 1.0 {
+			t.Fatalf("Float64() returned a value out of bounds: %f", val)
+		}
+		sum += val
+	}
+	if mean := sum / n; math.Abs(mean-0.5) > 0.02 {
+		t.Errorf("Float64() mean = %f; want ~0.5", mean)
+	}
+}
+
+func TestPCG32_Float32(t *testing.T) {
+	pcg := NewPCG32().Seed(42, 54)
+	sum := 0.0
+	const n = 100000
+	for i := 0; i < n; i++ {
+		val := pcg.Float32()
+		if val < 0.0 || val >= 1.0 {
+			t.Fatalf("Float32() returned a value out of bounds: %f", val)
+		}
+		sum += float64(val)
+	}
+	if mean := sum / n; math.Abs(mean-0.5) > 0.02 {
+		t.Errorf("Float32() mean = %f; want ~0.5", mean)
+	}
+
+	// the largest possible draw must still map below 1
+	if v := float32(uint32(math.MaxUint32)>>8) * (1.0 / (1 << 24)); v >= 1 {
+		t.Errorf("Float32() for the largest Uint32 = %v; want a value below 1", v)
+	}
+}
+
 func TestPCG32_UniformDistribution(t *testing.T) {
 	pcg := NewPCG32().Seed(12345, 67890)
 	numBins := 10
@@ -114,6 +242,121 @@ func TestPCG32_Advance(t *testing.T) {
 	}
 }
 
+func TestPCG32_AdvanceMatchesStepping(t *testing.T) {
+	for _, seq := range []uint64{0, 1, 54, 0xdeadbeef} {
+		stepped := NewPCG32().Seed(42, seq)
+		jumped := stepped.Clone()
+		for i := 0; i < 1000; i++ {
+			stepped.Uint32()
+		}
+		if jumped.Advance(1000); jumped.state != stepped.state {
+			t.Errorf("seq %d: Advance(1000) state = %d; want %d as after 1000 Uint32 calls", seq, jumped.state, stepped.state)
+		}
+	}
+}
+
+func TestExportedConstants(t *testing.T) {
+	p := NewPCG32()
+	if p.State() != DefaultState || p.Increment() != IncrementStep {
+		t.Errorf("NewPCG32() = (%#x, %#x); want (DefaultState, IncrementStep) = (%#x, %#x)",
+			p.State(), p.Increment(), DefaultState, IncrementStep)
+	}
+
+	// the arithmetic wraps modulo 2^64, so it must not be folded as constants
+	state, mul, inc := DefaultState, Multiplier, IncrementStep
+	p.Uint32()
+	if want := state*mul + inc; p.State() != want {
+		t.Errorf("state after one step = %#x; want DefaultState*Multiplier + IncrementStep = %#x", p.State(), want)
+	}
+
+	if want := (7+(5<<1|1))*mul + inc; NewPCG32().Seed(7, 5).State() != want {
+		t.Errorf("Seed(7, 5) state = %#x; want %#x", NewPCG32().Seed(7, 5).State(), want)
+	}
+}
+
+func TestPCG32_Clone(t *testing.T) {
+	pcg := NewPCG32().Seed(42, 54)
+	clone := pcg.Clone()
+	for i := 0; i < 10; i++ {
+		if got, want := clone.Uint32(), pcg.Uint32(); got != want {
+			t.Fatalf("#%d: Clone().Uint32() = %d; want %d", i, got, want)
+		}
+	}
+	clone.Advance(5)
+	if clone.state == pcg.state {
+		t.Errorf("advancing the clone also moved the original")
+	}
+}
+
+func TestPCG32_Distance(t *testing.T) {
+	deltas := []uint64{0, 1, 2, 3, 1000, 1<<32 + 7, 1 << 63, math.MaxUint64}
+
+	for _, seq := range []uint64{0, 54, 0xdeadbeef} {
+		p := NewPCG32().Seed(12345, seq)
+		for _, d := range deltas {
+			if got := p.Distance(p.Clone().Advance(d)); got != d {
+				t.Errorf("seq %d: Distance(Advance(%d)) = %d; want %d", seq, d, got, d)
+			}
+		}
+	}
+
+	p := NewPCG32().Seed(1, 2)
+	q := p.Clone()
+	for i := 0; i < 37; i++ {
+		q.Uint32()
+	}
+	if got := p.Distance(q); got != 37 {
+		t.Errorf("Distance after 37 Uint32 calls = %d; want 37", got)
+	}
+	if want := uint64(math.MaxUint64 - 36); q.Distance(p) != want {
+		t.Errorf("reverse Distance = %d; want %d (2^64 - 37)", q.Distance(p), want)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Distance between different streams did not panic")
+		}
+	}()
+	p.Distance(NewPCG32().Seed(1, 3))
+}
+
+func TestPCG32_Distance_EvenIncrement(t *testing.T) {
+	p := &PCG32{state: 1, increment: 4}
+	q := p.Clone()
+	q.Uint32()
+
+	done := make(chan any)
+	go func() {
+		defer func() { done <- recover() }()
+		p.Distance(q)
+	}()
+	select {
+	case r := <-done:
+		if r == nil {
+			t.Errorf("Distance() with an even increment did not panic")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Distance() with an even increment did not return")
+	}
+}
+
+func TestPCG32_Jump(t *testing.T) {
+	deltas := []int64{0, 1, -1, 1000, -1000, 1 << 40, math.MaxInt64, math.MinInt64}
+
+	for _, d := range deltas {
+		pcg := NewPCG32().Seed(42, 54)
+		initial := pcg.state
+		if pcg.Jump(d).Jump(-d); pcg.state != initial {
+			t.Errorf("Jump(%d) then Jump(%d) = %d; want %d", d, -d, pcg.state, initial)
+		}
+	}
+
+	p := NewPCG32().Seed(42, 54)
+	if got := p.Distance(p.Clone().Jump(-5)); got != math.MaxUint64-4 {
+		t.Errorf("Distance(Jump(-5)) = %d; want 2^64 - 5", got)
+	}
+}
+
 func TestPCG32_Retreat(t *testing.T) {
 	pcg := NewPCG32()
 
@@ -138,6 +381,69 @@ func TestPCG32_Retreat(t *testing.T) {
 	}
 }
 
+func TestPCG32_AdvanceRetreat_Zero(t *testing.T) {
+	pcg := NewPCG32().Seed(12345, 67890)
+	initial := pcg.state
+
+	if got := pcg.Advance(0).state; got != initial {
+		t.Errorf("Advance(0) state = %d; want %d", got, initial)
+	}
+	if got := pcg.Retreat(0).state; got != initial {
+		t.Errorf("Retreat(0) state = %d; want %d", got, initial)
+	}
+}
+
+func TestPCG32_AdvanceRetreat_ExtremeDelta(t *testing.T) {
+	deltas := []uint64{
+		math.MaxUint64,
+		1 << 63,
+		1<<63 + 1,
+		1<<63 - 1,
+		math.MaxUint64 - 1,
+	}
+
+	for _, delta := range deltas {
+		pcg := NewPCG32().Seed(12345, 67890)
+		initial := pcg.state
+
+		pcg.Advance(delta).Retreat(delta)
+		if pcg.state != initial {
+			t.Errorf("Advance(%d) then Retreat(%d) = %d; want %d", delta, delta, pcg.state, initial)
+		}
+
+		pcg.Retreat(delta).Advance(delta)
+		if pcg.state != initial {
+			t.Errorf("Retreat(%d) then Advance(%d) = %d; want %d", delta, delta, pcg.state, initial)
+		}
+	}
+
+	// The LCG has period 2^64, so advancing by 2^64-1 is a single step back
+	// and advancing by 2^63 twice is a full period.
+	pcg := NewPCG32()
+	stepBack := NewPCG32().Retreat(1).state
+	if got := pcg.Advance(math.MaxUint64).state; got != stepBack {
+		t.Errorf("Advance(MaxUint64) = %d; want %d (Retreat(1))", got, stepBack)
+	}
+
+	pcg = NewPCG32()
+	if got := pcg.Advance(1 << 63).Advance(1 << 63).state; got != defaultState {
+		t.Errorf("Advance(2^63) twice = %d; want %d", got, uint64(defaultState))
+	}
+}
+
+func TestPCG32_Skip(t *testing.T) {
+	for _, n := range []uint64{0, 1, 2, 10, 1000} {
+		skipped := NewPCG32().Seed(12345, 67890).Skip(n)
+		drawn := NewPCG32().Seed(12345, 67890)
+		for i := uint64(0); i < n; i++ {
+			drawn.Uint32()
+		}
+		if skipped.state != drawn.state {
+			t.Errorf("Skip(%d) state = %d; want %d", n, skipped.state, drawn.state)
+		}
+	}
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -321,6 +627,33 @@ func TestPCG32_Read(t *testing.T) {
 	}
 }
 
+func TestPCG32_ReadFast(t *testing.T) {
+	for _, size := range []int{0, 5, 15, 16, 17, 100, 4096, 4099} {
+		a := NewPCG32().Seed(42, 54)
+		b := NewPCG32().Seed(42, 54)
+
+		bufA := make([]byte, size)
+		bufB := make([]byte, size)
+		n, err := a.ReadFast(bufA)
+		if err != nil {
+			t.Fatalf("ReadFast() error = %v; want nil", err)
+		}
+		if n != size {
+			t.Errorf("ReadFast() n = %d; want %d", n, size)
+		}
+		b.ReadFast(bufB)
+		assert.Equal(t, bufA, bufB, "ReadFast(%d) must be deterministic for the same seed", size)
+		assert.Equal(t, a.Uint32(), b.Uint32(), "generator state after ReadFast(%d) must match", size)
+	}
+
+	// short buffers take the single-stream path
+	short := make([]byte, 15)
+	expected := make([]byte, 15)
+	NewPCG32().Seed(7, 8).ReadFast(short)
+	NewPCG32().Seed(7, 8).Read(expected)
+	assert.Equal(t, expected, short)
+}
+
 func BenchmarkPCG32Rand(b *testing.B) {
 	rng := NewPCG32()
 	for i := 0; i < b.N; i++ {
@@ -361,6 +694,27 @@ func BenchmarkPCG64_InRange_Uintn64(b *testing.B) {
 	}
 }
 
+// benchBound is a variable so the compiler cannot fold the bound into the per-call loop.
+var benchBound uint32 = 6
+
+func BenchmarkPCG32_FillUintn(b *testing.B) {
+	rng := NewPCG32()
+	dst := make([]uint32, 1024)
+	for i := 0; i < b.N; i++ {
+		rng.FillUintn(dst, benchBound)
+	}
+}
+
+func BenchmarkPCG32_Uintn32Loop(b *testing.B) {
+	rng := NewPCG32()
+	dst := make([]uint32, 1024)
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = rng.Uintn32(benchBound)
+		}
+	}
+}
+
 func Benchmark_MathRanIntn(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		rand.Intn(100)
@@ -383,6 +737,26 @@ func BenchmarkPCG64Read(b *testing.B) {
 	}
 }
 
+// BenchmarkPCG64Read_4KiB hits the aligned fast path of Read; the 4095-byte variant
+// below runs the general loop over almost the same amount of output.
+func BenchmarkPCG64Read_4KiB(b *testing.B) {
+	p := NewPCG64(42, 54)
+	buf := make([]byte, 4096)
+	b.SetBytes(int64(len(buf)))
+	for n := 0; n < b.N; n++ {
+		p.Read(buf)
+	}
+}
+
+func BenchmarkPCG64Read_4KiBUnaligned(b *testing.B) {
+	p := NewPCG64(42, 54)
+	buf := make([]byte, 4095)
+	b.SetBytes(int64(len(buf)))
+	for n := 0; n < b.N; n++ {
+		p.Read(buf)
+	}
+}
+
 func BenchmarkMathRandRead(b *testing.B) {
 	buf := make([]byte, 1024)
 	r := rand.New(rand.NewSource(0))
@@ -390,3 +764,97 @@ func BenchmarkMathRandRead(b *testing.B) {
 		r.Read(buf)
 	}
 }
+
+func BenchmarkPCG32Read_1MiB(b *testing.B) {
+	p := NewPCG32()
+	buf := make([]byte, 1<<20)
+	b.SetBytes(int64(len(buf)))
+	for n := 0; n < b.N; n++ {
+		p.Read(buf)
+	}
+}
+
+// uint32NoInline is Uint32 behind a call the compiler may not inline, standing in for a
+// Uint32 that is too large for the inliner.
+//
+//go:noinline
+func uint32NoInline(p *PCG32) uint32 {
+	return p.Uint32()
+}
+
+// BenchmarkPCG32Read_Inlining compares Read, whose loop inlines Uint32, against the same
+// loop making an out-of-line call per word. Both produce identical bytes.
+func BenchmarkPCG32Read_Inlining(b *testing.B) {
+	b.Run("inlined", func(b *testing.B) {
+		p := NewPCG32()
+		buf := make([]byte, 4096)
+		b.SetBytes(int64(len(buf)))
+		for n := 0; n < b.N; n++ {
+			p.Read(buf)
+		}
+	})
+	b.Run("call", func(b *testing.B) {
+		p := NewPCG32()
+		buf := make([]byte, 4096)
+		b.SetBytes(int64(len(buf)))
+		for n := 0; n < b.N; n++ {
+			for i := 0; i < len(buf); i += 4 {
+				binary.LittleEndian.PutUint32(buf[i:], uint32NoInline(p))
+			}
+		}
+	})
+}
+
+func BenchmarkPCG32ReadFast_1MiB(b *testing.B) {
+	p := NewPCG32()
+	buf := make([]byte, 1<<20)
+	b.SetBytes(int64(len(buf)))
+	for n := 0; n < b.N; n++ {
+		p.ReadFast(buf)
+	}
+}
+
+// BenchmarkPCG32_Uint32 measures single Uint32 draws and the methods built on them, all of
+// which inline Uint32.
+func BenchmarkPCG32_Uint32(b *testing.B) {
+	b.Run("Uint32", func(b *testing.B) {
+		p := NewPCG32()
+		var sink uint32
+		for i := 0; i < b.N; i++ {
+			sink += p.Uint32()
+		}
+		sinkUint32 = sink
+	})
+	b.Run("Uintn32", func(b *testing.B) {
+		p := NewPCG32()
+		var sink uint32
+		for i := 0; i < b.N; i++ {
+			sink += p.Uintn32(benchBound)
+		}
+		sinkUint32 = sink
+	})
+	b.Run("Uint64", func(b *testing.B) {
+		p := NewPCG32()
+		var sink uint64
+		for i := 0; i < b.N; i++ {
+			sink += p.Uint64()
+		}
+		sinkUint32 = uint32(sink)
+	})
+	b.Run("Float64", func(b *testing.B) {
+		p := NewPCG32()
+		var sink float64
+		for i := 0; i < b.N; i++ {
+			sink += p.Float64()
+		}
+		sinkUint32 = uint32(sink)
+	})
+	b.Run("Perm100", func(b *testing.B) {
+		p := NewPCG32()
+		for i := 0; i < b.N; i++ {
+			sinkInts = p.Perm(100)
+		}
+	})
+}
+
+var sinkUint32 uint32
@@ -1,22 +1,42 @@
 package pcg
 
 import (
+	"encoding"
 	"encoding/binary"
 	"errors"
 	"math"
+	"math/big"
 	"math/bits"
+	"math/rand"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
 const (
 	inv52 = 1.0 / (1 << 52)
-	inv64 = 1.0 / float64(math.MaxUint64)
+	inv53 = 1.0 / (1 << 53)
 )
 
 // A PCG64 is a PCG64 generator with 128 bits of internal state.
 // A zero PCG64 is equivalent to one seeded with 0.
+// A PCG64 is not safe for concurrent use by multiple goroutines; use LockedPCG64 to share one.
 type PCG64 struct {
 	hi, lo *PCG32
+
+	// spare holds the second normal of the last Box-Muller pair until NormFloat64 hands it out.
+	spare    float64
+	hasSpare bool
+
+	// antithetic holds the last Float64Antithetic draw until its complement is handed out.
+	antithetic    float64
+	hasAntithetic bool
+
+	// consumed counts the 64-bit outputs drawn since the last seed.
+	consumed uint64
+
+	// guard is non-nil once EnableForkDetection has been called.
+	guard *forkGuard
 }
 
 // NewPCG64 returns a new PCG64 generator seeded with thr given values.
@@ -28,8 +48,52 @@ func NewPCG64(seed1, seed2 uint64) *PCG64 {
 	}
 }
 
+// NewPCG64FromState returns a new PCG64 generator built directly from raw state and increment words,
+// bypassing the seeding transform. It is meant for resuming a generator from state captured elsewhere,
+// so the values are used as is and the increments should be odd as produced by Seed.
+func NewPCG64FromState(hiState, loState, hiInc, loInc uint64) *PCG64 {
+	return &PCG64{
+		hi: &PCG32{state: hiState, increment: hiInc},
+		lo: &PCG32{state: loState, increment: loInc},
+	}
+}
+
+// autoSeedCounter distinguishes generators created by NewPCG64Auto within the same clock tick.
+var autoSeedCounter atomic.Uint64
+
+// NewPCG64Auto returns a new PCG64 generator seeded from the current time mixed with
+// a per-call counter, so two generators created in the same nanosecond still differ.
+// It is meant for quick scripts and is non-reproducible by design; use NewPCG64 or Seed
+// when the stream must be repeatable.
+func NewPCG64Auto() *PCG64 {
+	x := uint64(time.Now().UnixNano()) ^ autoSeedCounter.Add(1)*incrementStep
+	return NewPCG64(0, 0).SeedInt64(int64(x))
+}
+
+// NewPCG64Streams returns n generators derived from baseSeed, for handing one to each worker.
+// Every generator starts from the same state words but runs on its own pair of sequences:
+// the i-th uses sequences 2i+1 and 2i+2, so all 2n halves have distinct increments and the
+// streams are independent. The same baseSeed and n always yield the same set.
+// It panics if n < 0.
+func NewPCG64Streams(baseSeed uint64, n int) []*PCG64 {
+	if n < 0 {
+		panic("invalid argument to NewPCG64Streams")
+	}
+	streams := make([]*PCG64, n)
+	for i := range streams {
+		seq := 2 * uint64(i)
+		streams[i] = NewPCG64(0, 0).Seed(baseSeed, ^baseSeed, seq+1, seq+2)
+	}
+	return streams
+}
+
 // Seed initializes the PCG64 generator with the given state and sequence values.
 // seed1 and seed2 are the initial state values, and seq1 and seq2 are the sequence values.
+//
+// A PCG32 increment is (seq << 1) | 1, so only the low 63 bits of a sequence value select
+// the stream. If seq1 and seq2 agree in those bits, seq2 is complemented before use. The
+// complement differs from seq1 in every one of the low 63 bits, so the two halves are
+// guaranteed to run on distinct streams for all inputs.
 func (p *PCG64) Seed(seed1, seed2, seq1, seq2 uint64) *PCG64 {
 	mask := ^uint64(0) >> 1
 	if seq1&mask == seq2&mask {
@@ -37,21 +101,174 @@ func (p *PCG64) Seed(seed1, seed2, seq1, seq2 uint64) *PCG64 {
 	}
 	p.lo.Seed(seed1, seq1)
 	p.hi.Seed(seed2, seq2)
+	p.hasSpare = false
+	p.hasAntithetic = false
+	p.consumed = 0
+
+	return p
+}
+
+// Seed2 reseeds the generator from two state values using the default sequences,
+// following the same convention as NewPCG64: seed1 seeds the high half and seed2 the low half.
+// NewPCG64(seed1, seed2) and NewPCG64(x, y).Seed2(seed1, seed2) produce the same stream.
+func (p *PCG64) Seed2(seed1, seed2 uint64) *PCG64 {
+	p.hi.Seed(seed1, 0)
+	p.lo.Seed(seed2, 0)
+	p.hasSpare = false
+	p.hasAntithetic = false
+	p.consumed = 0
 
 	return p
 }
 
+// SeedInt64 reseeds the generator from a single 64-bit seed, as a drop-in for rand.Seed(int64).
+// The seed is expanded into both state values and both sequence values with SplitMix64,
+// so the same seed always reproduces the same stream.
+func (p *PCG64) SeedInt64(seed int64) *PCG64 {
+	x := uint64(seed)
+	seed1 := splitMix64(&x)
+	seed2 := splitMix64(&x)
+	seq1 := splitMix64(&x)
+	seq2 := splitMix64(&x)
+	return p.Seed(seed1, seed2, seq1, seq2)
+}
+
+// SeedFromSource reseeds the generator with four words pulled from src, used in order as
+// seed1, seed2, seq1 and seq2 of Seed. This lets a PCG64 be seeded from any rand.Source64,
+// such as a crypto- or OS-backed source.
+func (p *PCG64) SeedFromSource(src rand.Source64) *PCG64 {
+	seed1 := src.Uint64()
+	seed2 := src.Uint64()
+	seq1 := src.Uint64()
+	seq2 := src.Uint64()
+	return p.Seed(seed1, seed2, seq1, seq2)
+}
+
+// splitMix64 advances x and returns the next output of the SplitMix64 generator.
+// ref: https://prng.di.unimi.it/splitmix64.c
+func splitMix64(x *uint64) uint64 {
+	*x += incrementStep
+	z := *x
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// State returns the current internal states of the high and low sub-generators without advancing them.
+func (p *PCG64) State() (hi, lo uint64) {
+	return p.hi.state, p.lo.state
+}
+
+// Increment returns the increments (stream selectors) of the high and low sub-generators.
+func (p *PCG64) Increment() (hi, lo uint64) {
+	return p.hi.increment, p.lo.increment
+}
+
+// Consumed returns how many Uint64 equivalents have been drawn since the generator was
+// seeded (or built by NewPCG64FromState or restored by UnmarshalBinary), for logging how far
+// into a stream a run has gone. Each Uint64 counts one, NextN counts n, Read counts one per
+// started 8 bytes, Skip counts the outputs it discards, and each Uint64nWithMCG draw counts one.
+// Methods built on them count the draws they make, including rejected ones. Advance, Retreat
+// and Jump reposition the generator without drawing and leave the count alone.
+func (p *PCG64) Consumed() uint64 {
+	return p.consumed
+}
+
+// Fingerprint returns a stable 64-bit hash of the full generator state (both states and
+// both increments), mixed with the SplitMix64 finalizer. It does not advance the generator,
+// and only depends on the state words, so it is the same across runs and platforms.
+func (p *PCG64) Fingerprint() uint64 {
+	var h uint64
+	for _, w := range [4]uint64{p.hi.state, p.lo.state, p.hi.increment, p.lo.increment} {
+		x := h ^ w
+		h = splitMix64(&x)
+	}
+	return h
+}
+
 // Uint64 generates a pseudorandom 64-bit unsigned integer using the PCG64 algorithm.
 func (p *PCG64) Uint64() uint64 {
+	if p.guard != nil {
+		return p.guardedUint64()
+	}
+	p.consumed++
 	return uint64(p.hi.Uint32())<<32 | uint64(p.lo.Uint32())
 }
 
+// NextN returns a freshly allocated slice of n consecutive Uint64 outputs.
+// It is equivalent to calling Uint64 n times, but keeps the sub-generator states
+// in local variables so they can stay in registers across the loop.
+func (p *PCG64) NextN(n int) []uint64 {
+	if n < 0 {
+		panic("invalid argument to NextN")
+	}
+
+	if p.guard != nil {
+		p.guard.enter()
+		defer p.guard.exit()
+	}
+
+	res := make([]uint64, n)
+	hiState, hiInc := p.hi.state, p.hi.increment
+	loState, loInc := p.lo.state, p.lo.increment
+	for i := range res {
+		hiOld, loOld := hiState, loState
+		hiState = hiOld*multiplier + hiInc
+		loState = loOld*multiplier + loInc
+		res[i] = uint64(permute(hiOld))<<32 | uint64(permute(loOld))
+	}
+	p.hi.state, p.lo.state = hiState, loState
+	p.consumed += uint64(n)
+	return res
+}
+
 // Uint63 generates a pseudorandom 63-bit integer using the PCG64 algorithm.
 // It masks the highest bit to ensure the value is within the 63-bit integer range.
 func (p *PCG64) Uint63() int64 {
 	return int64(p.Uint64() & 0x7FFFFFFFFFFFFFFF) // Mask the highest bit to stay within the 63-bit range
 }
 
+// Int63n returns a non-negative pseudo-random number in the half-open interval [0, n)
+// without modulo bias. It follows math/rand exactly: a power-of-two n masks a single Uint63
+// draw, and any other n rejects draws above the largest multiple of n below 2^63, so for
+// the same Uint63 stream both produce the same values.
+// It panics if n <= 0.
+func (p *PCG64) Int63n(n int64) int64 {
+	if n <= 0 {
+		panic("invalid argument to Int63n")
+	}
+	if n&(n-1) == 0 { // n is power of two, can mask
+		return p.Uint63() & (n - 1)
+	}
+	max := int64((1 << 63) - 1 - (1<<63)%uint64(n))
+	v := p.Uint63()
+	for v > max {
+		v = p.Uint63()
+	}
+	return v % n
+}
+
+// Int64Range returns a uniform pseudo-random int64 in the half-open interval [lo, hi).
+// The span hi - lo is computed in uint64, so any pair of int64 bounds works, including
+// spans wider than math.MaxInt64.
+// It panics if hi <= lo.
+func (p *PCG64) Int64Range(lo, hi int64) int64 {
+	if hi <= lo {
+		panic("invalid argument to Int64Range")
+	}
+	return lo + int64(p.Uint64n(uint64(hi)-uint64(lo)))
+}
+
+// IntRange returns a uniform pseudo-random int in the half-open interval [lo, hi),
+// with the same semantics and draws as Int64Range.
+// It panics if hi <= lo.
+func (p *PCG64) IntRange(lo, hi int) int {
+	if hi <= lo {
+		panic("invalid argument to IntRange")
+	}
+	return int(p.Int64Range(int64(lo), int64(hi)))
+}
+
 // Uint64n generates a pseudorandom number in the range [0, bound) using the PCG64 algorithm.
 func (p *PCG64) Uint64n(bound uint64) uint64 {
 	threshold := -bound % bound
@@ -63,15 +280,104 @@ func (p *PCG64) Uint64n(bound uint64) uint64 {
 	}
 }
 
+// Uint64nTry is Uint64n with a cap on the rejection loop, for callers that need a bound
+// on worst-case latency. It draws at most maxAttempts values and reports false if all of them
+// were rejected. Each attempt is accepted with probability 1 - (-bound%bound)/2^64, which is
+// always above 1/2 and close to 1 for most bounds, so the first attempt almost always succeeds.
+// Accepted values are distributed exactly as those of Uint64n.
+// It panics if bound == 0 or maxAttempts <= 0.
+func (p *PCG64) Uint64nTry(bound uint64, maxAttempts int) (uint64, bool) {
+	if bound == 0 || maxAttempts <= 0 {
+		panic("invalid argument to Uint64nTry")
+	}
+	threshold := -bound % bound
+	for i := 0; i < maxAttempts; i++ {
+		r := p.Uint64()
+		if r >= threshold {
+			return r % bound, true
+		}
+	}
+	return 0, false
+}
+
+// FillUint64n fills dst with pseudorandom numbers in the range [0, bound), drawing each
+// element through a BoundedU64. It produces the same values as calling Uint64n for each
+// element; it is a convenience for filling grids, not a faster path. A bound of 0 fills
+// dst with zeros.
+func (p *PCG64) FillUint64n(dst []uint64, bound uint64) {
+	if bound == 0 {
+		for i := range dst {
+			dst[i] = 0
+		}
+		return
+	}
+
+	b := BoundedU64{p: p, bound: bound, threshold: -bound % bound}
+	for i := range dst {
+		dst[i] = b.Next()
+	}
+}
+
 // Float64 returns a random float64 in the range [0.0, 1.0).
 func (p *PCG64) Float64() float64 {
 	return float64(p.Uint63()>>11) * inv52
 }
 
-// Float64Full uses the full 64 bits of the generated number to produce a random float64.
-// slightly more precise than Float64() but slower.
+// Float64Full returns a random float64 in the range [0.0, 1.0).
+// It keeps the top 53 bits of a full 64-bit draw, which is the entire precision of
+// a float64 mantissa, so it is slightly more precise than Float64() but slower.
 func (p *PCG64) Float64Full() float64 {
-	return float64(p.Uint64()&0xFFFFFFFFFFFFFF) * inv64
+	return float64(p.Uint64()>>11) * inv53
+}
+
+// Float64Antithetic returns random float64s in the open interval (0.0, 1.0) in antithetic
+// pairs for variance reduction: odd calls return a fresh nonzero Float64Full draw U, and the
+// following call returns 1-U. Rejecting U = 0 keeps the complement below 1, and 1-U is exact
+// because U is a multiple of 2^-53. Each value is uniform on its own, but the two halves of a
+// pair are perfectly anti-correlated.
+func (p *PCG64) Float64Antithetic() float64 {
+	if p.hasAntithetic {
+		p.hasAntithetic = false
+		return 1 - p.antithetic
+	}
+	u := p.Float64Full()
+	for u == 0 {
+		u = p.Float64Full()
+	}
+	p.antithetic, p.hasAntithetic = u, true
+	return u
+}
+
+// Float64MathRandCompat returns a random float64 in the range [0.0, 1.0) using the
+// Int63n(1<<53) / (1<<53) transform of math/rand. Int63n takes the power-of-two fast path
+// and masks the low 53 bits of Uint63, so this keeps the low 53 bits of a draw, whereas
+// Float64 keeps the top 52 bits. The result is always a multiple of 2^-53 below 1.
+func (p *PCG64) Float64MathRandCompat() float64 {
+	return float64(p.Uint63()&(1<<53-1)) * inv53
+}
+
+// Float64OpenOpen returns a random float64 in the open interval (0.0, 1.0).
+// It takes the top 52 bits of a Uint64 draw as k and returns (k + 0.5) / 2^52, i.e. the
+// odd multiples of 2^-53. Every such value needs at most 53 significant bits, so it is exact,
+// and the smallest and largest results are 2^-53 and 1 - 2^-53: neither endpoint can be produced.
+func (p *PCG64) Float64OpenOpen() float64 {
+	return openOpen(p.Uint64())
+}
+
+func openOpen(x uint64) float64 {
+	return (float64(x>>12) + 0.5) * inv52
+}
+
+// Float64OpenClosed returns a random float64 in the half-open interval (0.0, 1.0].
+// It takes the top 53 bits of a Uint64 draw as k and returns (k + 1) / 2^53. Since k + 1 lies
+// in [1, 2^53] it is exactly representable, so 0 is never produced and 1 is produced only for
+// the all-ones draw.
+func (p *PCG64) Float64OpenClosed() float64 {
+	return openClosed(p.Uint64())
+}
+
+func openClosed(x uint64) float64 {
+	return (float64(x>>11) + 1) * inv53
 }
 
 // Advance moves the PCG64 generator forward by `delta` steps.
@@ -82,14 +388,46 @@ func (p *PCG64) Advance(delta uint64) *PCG64 {
 	return p
 }
 
+// Skip discards the next n outputs, leaving the generator in exactly the same state as
+// n discarded Uint64 calls, without computing the output permutations.
+func (p *PCG64) Skip(n uint64) *PCG64 {
+	p.hi.Skip(n)
+	p.lo.Skip(n)
+	p.consumed += n
+	return p
+}
+
 // Retreat moves the PCG64 generator backward by `delta` steps.
 // it updates the initial state of the generator.
+// Moving back delta steps is the same as advancing by the two's complement of delta;
+// for delta == 0 that complement is 0 again, so Retreat(0) is a no-op.
 func (p *PCG64) Retreat(delta uint64) *PCG64 {
-	safeDelta := ^uint64(0) - 1
+	safeDelta := ^delta + 1
 	p.Advance(safeDelta)
 	return p
 }
 
+// Period returns the period of the Uint64 stream, 2^64. The two PCG32 halves have the same
+// period and always step together, so the pair of states, and with it the output, repeats
+// after 2^64 draws rather than 2^128. The 128-bit stream of Uint64nWithMCG, which treats
+// both states as a single 128-bit LCG, has a period of 2^128.
+func (p *PCG64) Period() *big.Int {
+	return p.hi.Period()
+}
+
+// Jump moves the generator by delta steps: forward with Advance for positive delta and
+// backward with Retreat for negative delta, so Jump(n) followed by Jump(-n) is a no-op.
+func (p *PCG64) Jump(delta int64) *PCG64 {
+	if delta < 0 {
+		return p.Retreat(uint64(-delta))
+	}
+	return p.Advance(uint64(delta))
+}
+
+// Shuffle pseudo-randomizes the order of n elements with a Fisher-Yates shuffle,
+// calling swap to exchange elements i and j. Every swap index is drawn with Uint64n
+// over uint64 arithmetic, so the sequence of swaps depends only on the generator state
+// and n: 32- and 64-bit builds produce the same permutation for the same seed.
 func (p *PCG64) Shuffle(n int, swap func(i, j int)) {
 	// Fisher-Yates shuffle: https://en.wikipedia.org/wiki/Fisher%E2%80%93Yates_shuffle
 	for i := n - 1; i > 0; i-- {
@@ -98,21 +436,54 @@ func (p *PCG64) Shuffle(n int, swap func(i, j int)) {
 	}
 }
 
+// Perm returns a slice of n ints holding a random permutation of the integers [0, n).
+// It is built on Shuffle, so the permutation is the same on every platform for the same seed.
 func (p *PCG64) Perm(n int) []int {
 	res := make([]int, n)
+	p.PermInto(res)
+	return res
+}
+
+// PermInto fills dst with a random permutation of the integers [0, len(dst)).
+// It produces the same permutation as Perm(len(dst)) but lets callers reuse dst across calls.
+func (p *PCG64) PermInto(dst []int) {
+	for i := range dst {
+		dst[i] = i
+	}
+	p.Shuffle(len(dst), func(i, j int) {
+		dst[i], dst[j] = dst[j], dst[i]
+	})
+}
+
+// Perm64 returns a slice of n int64s holding a random permutation of the integers [0, n).
+// Unlike Perm it is not limited by the width of int, and swap indices are drawn with Uint64n,
+// so it supports the full 63-bit range in principle (subject to available memory).
+// It panics if n < 0.
+func (p *PCG64) Perm64(n int64) []int64 {
+	if n < 0 {
+		panic("invalid argument to Perm64")
+	}
+	res := make([]int64, n)
 	for i := range res {
-		res[i] = i
+		res[i] = int64(i)
 	}
-	p.Shuffle(n, func(i, j int) {
+	for i := n - 1; i > 0; i-- {
+		j := int64(p.Uint64n(uint64(i + 1)))
 		res[i], res[j] = res[j], res[i]
-	})
+	}
 	return res
 }
 
 // Read generates random bytes in the provided byte slice using the PCG64 random number generator.
 // It employs loop unrolling to process 16 bytes at a time for performance enhancement.
+// Buffers whose length is a multiple of 16 take a faster path with no tail handling.
+// A nil or empty buf returns (0, nil) without drawing from the generator.
 func (p *PCG64) Read(buf []byte) (int, error) {
 	n := len(buf)
+	if n%16 == 0 && p.guard == nil {
+		p.readAligned(buf)
+		return n, nil
+	}
 	i := 0
 
 	// Loop unrolling: processing 16 bytes per iteration
@@ -140,35 +511,120 @@ func (p *PCG64) Read(buf []byte) (int, error) {
 	return n, nil
 }
 
+// readAligned is the fast path of Read for lengths that are a multiple of 16.
+// It keeps both sub-generator states in local variables, as NextN does, and has no tail
+// handling, while writing exactly the bytes the general loop would.
+func (p *PCG64) readAligned(buf []byte) {
+	hiState, hiInc := p.hi.state, p.hi.increment
+	loState, loInc := p.lo.state, p.lo.increment
+	for b := buf; len(b) >= 16; b = b[16:] {
+		v1 := uint64(permute(hiState))<<32 | uint64(permute(loState))
+		hiState = hiState*multiplier + hiInc
+		loState = loState*multiplier + loInc
+		v2 := uint64(permute(hiState))<<32 | uint64(permute(loState))
+		hiState = hiState*multiplier + hiInc
+		loState = loState*multiplier + loInc
+		binary.LittleEndian.PutUint64(b[0:8], v1)
+		binary.LittleEndian.PutUint64(b[8:16], v2)
+	}
+	p.hi.state, p.lo.state = hiState, loState
+	p.consumed += uint64(len(buf) / 8)
+}
+
+// Block16 returns a 16-byte block filled from two Uint64 draws in little-endian order.
+// It produces the same bytes as Read on a 16-byte buffer without allocating.
+func (p *PCG64) Block16() [16]byte {
+	var b [16]byte
+	binary.LittleEndian.PutUint64(b[0:], p.Uint64())
+	binary.LittleEndian.PutUint64(b[8:], p.Uint64())
+	return b
+}
+
+// Block32 returns a 32-byte block filled from four Uint64 draws in little-endian order.
+// It produces the same bytes as Read on a 32-byte buffer without allocating.
+func (p *PCG64) Block32() [32]byte {
+	var b [32]byte
+	binary.LittleEndian.PutUint64(b[0:], p.Uint64())
+	binary.LittleEndian.PutUint64(b[8:], p.Uint64())
+	binary.LittleEndian.PutUint64(b[16:], p.Uint64())
+	binary.LittleEndian.PutUint64(b[24:], p.Uint64())
+	return b
+}
+
 func beUint64(b []byte) uint64 {
 	_ = b[7]
 	return uint64(b[7]) | uint64(b[6])<<8 | uint64(b[5])<<16 | uint64(b[4])<<24 |
 		uint64(b[3])<<32 | uint64(b[2])<<40 | uint64(b[1])<<48 | uint64(b[0])<<56
 }
 
-func bePutUint64(b []byte, v uint64) {
-	_ = b[7]
-	b[0] = byte(v >> 56)
-	b[1] = byte(v >> 48)
-	b[2] = byte(v >> 40)
-	b[3] = byte(v >> 32)
-	b[4] = byte(v >> 24)
-	b[5] = byte(v >> 16)
-	b[6] = byte(v >> 8)
-	b[7] = byte(v)
-}
-
 // MarshalBinaryPCG64 serializes the state of the PCG64 generator to a binary format.
 // It returns the serialized state as a byte slice.
+//
+// The encoding is the prefix "pcg:", the big-endian hi and lo states, and a flag byte.
+// Bit 0 of the flag is set when a NormFloat64 spare is pending and bit 1 when a
+// Float64Antithetic complement is pending; the big-endian bits of each pending value
+// follow in that order. Restoring it therefore continues every stream exactly where it
+// left off. Bit 2 marks the optional Consumed count, which only MarshalBinaryWithConsumed
+// writes, as a big-endian uint64 after the other values.
 func (p *PCG64) MarshalBinaryPCG64() ([]byte, error) {
-	b := make([]byte, 20)
-	copy(b, "pcg:")
-	bePutUint64(b[4:], p.hi.state)
-	bePutUint64(b[4+8:], p.lo.state)
-	return b, nil
+	return p.AppendBinary(make([]byte, 0, 37))
+}
+
+// MarshalBinaryWithConsumed is MarshalBinaryPCG64 with the Consumed count appended, for
+// callers that want it to survive a round trip; UnmarshalBinary restores it from this encoding
+// and resets it to 0 otherwise.
+func (p *PCG64) MarshalBinaryWithConsumed() ([]byte, error) {
+	b, err := p.AppendBinary(make([]byte, 0, 45))
+	if err != nil {
+		return nil, err
+	}
+	b[20] |= flagConsumed
+	return binary.BigEndian.AppendUint64(b, p.Consumed()), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the same bytes as
+// MarshalBinaryPCG64, so *PCG64 can be used with encoding/gob and other standard encoders.
+func (p *PCG64) MarshalBinary() ([]byte, error) {
+	return p.MarshalBinaryPCG64()
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*PCG64)(nil)
+	_ encoding.BinaryUnmarshaler = (*PCG64)(nil)
+)
+
+// AppendBinary appends the binary encoding of the generator state (the same bytes as
+// MarshalBinaryPCG64) to dst and returns the extended slice, following the encoding.BinaryAppender
+// pattern. Reusing dst across calls avoids any allocation once it has enough capacity.
+func (p *PCG64) AppendBinary(dst []byte) ([]byte, error) {
+	dst = append(dst, "pcg:"...)
+	dst = binary.BigEndian.AppendUint64(dst, p.hi.state)
+	dst = binary.BigEndian.AppendUint64(dst, p.lo.state)
+	var flag byte
+	if p.hasSpare {
+		flag |= flagSpare
+	}
+	if p.hasAntithetic {
+		flag |= flagAntithetic
+	}
+	dst = append(dst, flag)
+	if p.hasSpare {
+		dst = binary.BigEndian.AppendUint64(dst, math.Float64bits(p.spare))
+	}
+	if p.hasAntithetic {
+		dst = binary.BigEndian.AppendUint64(dst, math.Float64bits(p.antithetic))
+	}
+	return dst, nil
 }
 
+// littleEndian reports whether the host stores the low byte of a word first.
+var littleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// bePutUint64Unsafe stores v into b[:8] in big-endian order with a single word store.
 func bePutUint64Unsafe(b []byte, v uint64) {
+	if littleEndian {
+		v = bits.ReverseBytes64(v)
+	}
 	*(*uint64)(unsafe.Pointer(&b[0])) = v
 }
 
@@ -176,6 +632,8 @@ func bePutUint64Unsafe(b []byte, v uint64) {
 // It returns the serialized state as a byte slice.
 // This method does not allocate any memory and is about 30 times faster than the safe version.
 // However, it should be used with caution as it relies on unsafe operations.
+// It writes the older 20-byte encoding: the prefix and the big-endian states, with no flag byte,
+// so UnmarshalBinary restores the states but no pending NormFloat64 or Float64Antithetic value.
 func (p *PCG64) MarshalBinaryUnsafe() ([]byte, error) {
 	b := make([]byte, 20)
 	*(*uint32)(unsafe.Pointer(&b[0])) = *(*uint32)(unsafe.Pointer(&[4]byte{'p', 'c', 'g', ':'}))
@@ -186,38 +644,121 @@ func (p *PCG64) MarshalBinaryUnsafe() ([]byte, error) {
 
 var errUnmarshalPCG = errors.New("invalid PCG encoding")
 
+// Bits of the flag byte that follows the states in the binary encoding.
+const (
+	flagSpare      = 1 << 0
+	flagAntithetic = 1 << 1
+	flagConsumed   = 1 << 2
+)
+
 // UnmarshalBinaryPCG64 deserializes the state of the PCG64 generator from a binary format.
-// It takes the serialized state as a byte slice and updates the generator's state.
+// It takes the serialized state as a byte slice and updates the generator's state,
+// including any pending NormFloat64 spare and Float64Antithetic complement, and the Consumed
+// count when it was written by MarshalBinaryWithConsumed. The older 20-byte encoding without
+// the flag byte (still produced by MarshalBinaryUnsafe) is accepted and restores none of them.
 func (p *PCG64) UnmarshalBinary(b []byte) error {
-	if len(b) != 20 || string(b[:4]) != "pcg:" {
+	if len(b) < 20 || string(b[:4]) != "pcg:" {
 		return errUnmarshalPCG
 	}
+	var flag byte
+	if len(b) > 20 {
+		flag = b[20]
+	}
+	want := 21 + 8*bits.OnesCount8(flag)
+	if len(b) == 20 {
+		want = 20
+	}
+	if flag&^(flagSpare|flagAntithetic|flagConsumed) != 0 || len(b) != want {
+		return errUnmarshalPCG
+	}
+	rest := b[min(len(b), 21):]
+	p.hasSpare = flag&flagSpare != 0
+	if p.hasSpare {
+		p.spare = math.Float64frombits(beUint64(rest))
+		rest = rest[8:]
+	}
+	p.hasAntithetic = flag&flagAntithetic != 0
+	if p.hasAntithetic {
+		p.antithetic = math.Float64frombits(beUint64(rest))
+		rest = rest[8:]
+	}
 	p.hi.state = beUint64(b[4:])
 	p.lo.state = beUint64(b[4+8:])
+	p.consumed = 0
+	if flag&flagConsumed != 0 {
+		p.consumed = beUint64(rest)
+	}
 	return nil
 }
 
+// The multiplier and increment of the 128-bit LCG stepped by next, split into 64-bit halves.
+const (
+	mcgMulHi = 2549297995355413924
+	mcgMulLo = 4865540595714422341
+	mcgIncHi = 6364136223846793005
+	mcgIncLo = 1442695040888963407
+)
+
 func (p *PCG64) next() (uint64, uint64) {
-	const (
-		mulHi = 2549297995355413924
-		mulLo = 4865540595714422341
-		incHi = 6364136223846793005
-		incLo = 1442695040888963407
-	)
+	if p.guard != nil {
+		p.guard.enter()
+		defer p.guard.exit()
+	}
 
 	// state = state * mul + inc
-	hi, lo := bits.Mul64(p.lo.state, mulLo)
-	hi += p.hi.state*mulLo + p.lo.state*mulHi
-
-	lo, c := bits.Add64(lo, incLo, 0)
-	hi, _ = bits.Add64(hi, incHi, c)
+	hi, lo := mul128(p.hi.state, p.lo.state, mcgMulHi, mcgMulLo)
+	hi, lo = add128(hi, lo, mcgIncHi, mcgIncLo)
 
 	p.lo.state = lo
 	p.hi.state = hi
+	p.consumed++
 
 	return hi, lo
 }
 
+// mul128 returns the low 128 bits of the product of two 128-bit values.
+func mul128(aHi, aLo, bHi, bLo uint64) (hi, lo uint64) {
+	hi, lo = bits.Mul64(aLo, bLo)
+	hi += aHi*bLo + aLo*bHi
+	return hi, lo
+}
+
+// add128 returns the sum of two 128-bit values modulo 2^128.
+func add128(aHi, aLo, bHi, bLo uint64) (hi, lo uint64) {
+	lo, c := bits.Add64(aLo, bLo, 0)
+	hi, _ = bits.Add64(aHi, bHi, c)
+	return hi, lo
+}
+
+// AdvanceMCG moves the 128-bit stream of Uint64nWithMCG forward by delta steps in O(log delta)
+// time, using the same binary exponentiation as PCG32's advancedLCG64 carried out in 128-bit
+// arithmetic. It lets the stream be split into non-overlapping blocks for parallel work: a copy
+// advanced by k produces the values the original would produce after k draws.
+// Since the 128-bit state is the pair of PCG32 states, this also moves the Uint64 stream.
+func (p *PCG64) AdvanceMCG(delta uint64) *PCG64 {
+	accMulHi, accMulLo := uint64(0), uint64(1)
+	accAddHi, accAddLo := uint64(0), uint64(0)
+	mulHi, mulLo := uint64(mcgMulHi), uint64(mcgMulLo)
+	addHi, addLo := uint64(mcgIncHi), uint64(mcgIncLo)
+
+	for delta > 0 {
+		if delta&1 != 0 {
+			accMulHi, accMulLo = mul128(accMulHi, accMulLo, mulHi, mulLo)
+			accAddHi, accAddLo = mul128(accAddHi, accAddLo, mulHi, mulLo)
+			accAddHi, accAddLo = add128(accAddHi, accAddLo, addHi, addLo)
+		}
+		// add = (mul + 1) * add
+		m1Hi, m1Lo := add128(mulHi, mulLo, 0, 1)
+		addHi, addLo = mul128(m1Hi, m1Lo, addHi, addLo)
+		mulHi, mulLo = mul128(mulHi, mulLo, mulHi, mulLo)
+		delta >>= 1
+	}
+
+	hi, lo := mul128(accMulHi, accMulLo, p.hi.state, p.lo.state)
+	p.hi.state, p.lo.state = add128(hi, lo, accAddHi, accAddLo)
+	return p
+}
+
 // NextUInt64WithMCG generates a pseudorandom 64-bit unsigned integer using the PCG64 algorithm with Multiplier Congruential Generator (MCG).
 // It updates the internal state of the generator and returns the generated value.
 func (p *PCG64) Uint64nWithMCG() uint64 {
@@ -232,3 +773,32 @@ func (p *PCG64) Uint64nWithMCG() uint64 {
 
 	return hi
 }
+
+// Float64MCG returns a random float64 in the range [0.0, 1.0) whose 53-bit mantissa is
+// taken from the top of a Uint64nWithMCG draw, i.e. from the 128-bit LCG stream over the
+// combined state with its DXSM-style output function, instead of the two PCG32 halves.
+// With a single 128-bit state the stream is statistically stronger than two independent
+// 64-bit halves, at the cost of a 128-bit multiply per draw.
+// Both streams advance the same state, so interleaving Float64MCG with Float64 or Uint64
+// calls mixes the two sequences.
+func (p *PCG64) Float64MCG() float64 {
+	return float64(p.Uint64nWithMCG()>>11) * inv53
+}
+
+// ReferenceStream returns the first count outputs of the 128-bit MCG stream (Uint64nWithMCG)
+// of a generator seeded with Seed(seed1, seed2, seq1, seq2). It is a regression fixture for this
+// package only: the seeding and the output function (hi>>22 mixing with a 64-bit multiplier) are
+// specific to this implementation, so the values do not match other PCG libraries. Use it to check
+// that a build or a port of this package still produces the same stream.
+// It panics if count < 0.
+func ReferenceStream(seed1, seed2, seq1, seq2 uint64, count int) []uint64 {
+	if count < 0 {
+		panic("invalid argument to ReferenceStream")
+	}
+	p := NewPCG64(0, 0).Seed(seed1, seed2, seq1, seq2)
+	res := make([]uint64, count)
+	for i := range res {
+		res[i] = p.Uint64nWithMCG()
+	}
+	return res
+}
@@ -1,13 +1,19 @@
 package pcg
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
 	"fmt"
 	"math"
+	"math/big"
 	"math/rand"
 	"testing"
 	"time"
 
 	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
 )
 
 func TestUniformityOfUint63(t *testing.T) {
@@ -45,6 +51,184 @@ func TestUniformityOfUint63(t *testing.T) {
 	}
 }
 
+// uint63Source adapts a PCG64 to rand.Source so math/rand can consume the same Uint63 stream.
+type uint63Source struct{ p *PCG64 }
+
+func (s uint63Source) Int63() int64 { return s.p.Uint63() }
+
+func (s uint63Source) Seed(int64) {}
+
+func TestPCG64_Int63n(t *testing.T) {
+	bounds := []int64{1, 2, 7, 1 << 40, 1<<62 + 1, 3 << 61, math.MaxInt64}
+
+	for _, n := range bounds {
+		pcg := NewPCG64(42, 54)
+		ref := rand.New(uint63Source{NewPCG64(42, 54)})
+		for i := 0; i < 1000; i++ {
+			got, want := pcg.Int63n(n), ref.Int63n(n)
+			if got != want {
+				t.Fatalf("Int63n(%d) #%d = %d; want %d as math/rand", n, i, got, want)
+			}
+			if got < 0 || got >= n {
+				t.Fatalf("Int63n(%d) = %d; want a value in [0, %d)", n, got, n)
+			}
+		}
+	}
+
+	// 2^63 is not a multiple of n = 3<<61, so a plain Uint63() % n would draw [0, 1<<61)
+	// twice as often as the rest of the range; rejection must keep all bins level.
+	const n = 3 << 61
+	chiSquareP := func(draw func() int64) float64 {
+		const samples, bins = 2000, 4
+		observed := make([]float64, bins)
+		expected := make([]float64, bins)
+		for i := range expected {
+			expected[i] = samples / bins
+		}
+		for i := 0; i < samples; i++ {
+			observed[int(float64(draw())/float64(n)*bins)]++
+		}
+		return (distuv.ChiSquared{K: bins - 1}).Survival(stat.ChiSquare(observed, expected))
+	}
+
+	pcg := NewPCG64(12345, 67890)
+	if p := chiSquareP(func() int64 { return pcg.Int63n(n) }); p < 0.001 {
+		t.Errorf("Int63n(%d) chi-square p-value = %f; want a uniform distribution", int64(n), p)
+	}
+	if p := chiSquareP(func() int64 { return pcg.Uint63() % n }); p >= 0.001 {
+		t.Errorf("Uint63() %% %d chi-square p-value = %f; want the modulo bias to be detected", int64(n), p)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Int63n(0) did not panic")
+		}
+	}()
+	NewPCG64(1, 2).Int63n(0)
+}
+
+func TestPCG64_Uint64nTry(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	ref := NewPCG64(42, 54)
+
+	for _, bound := range []uint64{1, 6, 1000, 1 << 40, math.MaxUint64} {
+		for i := 0; i < 1000; i++ {
+			got, ok := pcg.Uint64nTry(bound, 1)
+			if !ok {
+				t.Fatalf("Uint64nTry(%d, 1) failed; want success on the first try", bound)
+			}
+			if want := ref.Uint64n(bound); got != want {
+				t.Fatalf("Uint64nTry(%d, 1) = %d; want %d as Uint64n", bound, got, want)
+			}
+		}
+	}
+
+	// For bound 2^63+1 nearly half of all draws are rejected; one attempt must
+	// consume exactly one draw whether it succeeds or not.
+	const halfRejected = 1<<63 + 1
+	failures := 0
+	for i := 0; i < 1000; i++ {
+		if _, ok := pcg.Uint64nTry(halfRejected, 1); !ok {
+			failures++
+		}
+		ref.Uint64()
+	}
+	if failures < 400 || failures > 600 {
+		t.Errorf("Uint64nTry(2^63+1, 1) failed %d times in 1000; want ~500", failures)
+	}
+	if got, want := pcg.Uint64(), ref.Uint64(); got != want {
+		t.Errorf("Uint64() after capped attempts = %#x; want %#x", got, want)
+	}
+
+	// A stuck generator that only ever returns 0 is always rejected for this bound.
+	stuck := NewPCG64FromState(0, 0, 0, 0)
+	if v, ok := stuck.Uint64nTry(halfRejected, 100); ok {
+		t.Errorf("Uint64nTry on a stuck generator = %d, true; want failure", v)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Uint64nTry(6, 0) did not panic")
+		}
+	}()
+	pcg.Uint64nTry(6, 0)
+}
+
+func TestPCG64_FillUint64n(t *testing.T) {
+	for _, bound := range []uint64{1, 6, 1000, 1<<63 + 1, math.MaxUint64} {
+		pcg := NewPCG64(42, 54)
+		ref := NewPCG64(42, 54)
+
+		dst := make([]uint64, 1000)
+		pcg.FillUint64n(dst, bound)
+		for i, got := range dst {
+			if want := ref.Uint64n(bound); got != want {
+				t.Fatalf("FillUint64n(%d)[%d] = %d; want %d", bound, i, got, want)
+			}
+		}
+		if got, want := pcg.Uint64(), ref.Uint64(); got != want {
+			t.Errorf("Uint64() after FillUint64n(%d) = %#x; want %#x", bound, got, want)
+		}
+	}
+
+	dst := []uint64{1, 2, 3}
+	NewPCG64(1, 2).FillUint64n(dst, 0)
+	for i, v := range dst {
+		if v != 0 {
+			t.Errorf("FillUint64n(0)[%d] = %d; want 0", i, v)
+		}
+	}
+}
+
+func TestPCG64_Int64Range(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct{ lo, hi int64 }{
+		{0, 1},
+		{-5, 5},
+		{-1000, -990},
+		{math.MinInt64, math.MaxInt64},
+		{math.MaxInt64 - 3, math.MaxInt64},
+	}
+
+	for _, tc := range tests {
+		for i := 0; i < 1000; i++ {
+			if v := pcg.Int64Range(tc.lo, tc.hi); v < tc.lo || v >= tc.hi {
+				t.Fatalf("Int64Range(%d, %d) = %d; want a value in [lo, hi)", tc.lo, tc.hi, v)
+			}
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Int64Range(3, 3) did not panic")
+		}
+	}()
+	pcg.Int64Range(3, 3)
+}
+
+func TestPCG64_IntRange(t *testing.T) {
+	a, b := NewPCG64(42, 54), NewPCG64(42, 54)
+	for _, tc := range []struct{ lo, hi int }{{0, 1}, {-5, 5}, {math.MinInt, math.MaxInt}} {
+		for i := 0; i < 1000; i++ {
+			v := a.IntRange(tc.lo, tc.hi)
+			if v < tc.lo || v >= tc.hi {
+				t.Fatalf("IntRange(%d, %d) = %d; want a value in [lo, hi)", tc.lo, tc.hi, v)
+			}
+			if want := b.Int64Range(int64(tc.lo), int64(tc.hi)); int64(v) != want {
+				t.Fatalf("IntRange(%d, %d) = %d; want %d as from Int64Range", tc.lo, tc.hi, v, want)
+			}
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("IntRange(3, 2) did not panic")
+		}
+	}()
+	a.IntRange(3, 2)
+}
+
 func TestPCG_Uint63(t *testing.T) {
 	pcg := NewPCG64(12345, 67890)
 
@@ -61,26 +245,17 @@ func TestPCG_Uint63(t *testing.T) {
 
 func TestPCG_Advance(t *testing.T) {
 	pcg := NewPCG64(12345, 67890)
+	ref := NewPCG64(12345, 67890)
 
-	tests := []struct {
-		delta           uint64
-		expectedStateHi uint64
-		expectedStateLo uint64
-	}{
-		{1, 5288318170876267920, 8586121761925336369},
-		{10, 658778831176772942, 680070833917286903},
-		{100, 11328633206433140426, 2423557365805501827},
-		{1000, 14055077221971198434, 7063816904067589179},
-		{10000, 3283529072340023762, 12459493142276168811},
-	}
-
-	for _, tc := range tests {
-		pcg.Advance(tc.delta)
-		if pcg.hi.state != tc.expectedStateHi {
-			t.Errorf("Advance(%d) hi state = %d; expected %d", tc.delta, pcg.hi.state, tc.expectedStateHi)
+	for _, delta := range []uint64{1, 10, 100, 1000, 10000} {
+		pcg.Advance(delta)
+		for i := uint64(0); i < delta; i++ {
+			ref.Uint64()
 		}
-		if pcg.lo.state != tc.expectedStateLo {
-			t.Errorf("Advance(%d) lo state = %d; expected %d", tc.delta, pcg.lo.state, tc.expectedStateLo)
+		gotHi, gotLo := pcg.State()
+		wantHi, wantLo := ref.State()
+		if gotHi != wantHi || gotLo != wantLo {
+			t.Errorf("Advance(%d) state = (%d, %d); want (%d, %d) from %d Uint64 calls", delta, gotHi, gotLo, wantHi, wantLo, delta)
 		}
 	}
 }
@@ -118,30 +293,413 @@ func TestPCG(t *testing.T) {
 	}
 }
 
-func TestPCG_Retreat(t *testing.T) {
-	pcg := NewPCG64(12345, 67890)
+func TestPCG64_Float64MCG(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	ref := NewPCG64(42, 54)
+
+	const n, bins = 200000, 20
+	samples := make([]float64, n)
+	for i := range samples {
+		samples[i] = pcg.Float64MCG()
+		if samples[i] < 0 || samples[i] >= 1 {
+			t.Fatalf("Float64MCG() = %v; want a value in [0, 1)", samples[i])
+		}
+		if want := float64(ref.Uint64nWithMCG()>>11) / (1 << 53); samples[i] != want {
+			t.Fatalf("Float64MCG() #%d = %v; want %v from Uint64nWithMCG", i, samples[i], want)
+		}
+	}
+
+	expected := float64(n) / bins
+	chi := 0.0
+	for _, c := range Histogram(samples, bins, 0, 1) {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	if p := chiSquareSurvival(chi, bins-1); p < 0.001 {
+		t.Errorf("Float64MCG() chi-square = %f (p = %g); want a uniform distribution", chi, p)
+	}
+}
+
+func TestPCG64_AdvanceMCG(t *testing.T) {
+	for _, d := range []uint64{0, 1, 2, 3, 100, 1000} {
+		stepped := NewPCG64(0, 0).Seed(12345, 67890, 1, 2)
+		for i := uint64(0); i < d; i++ {
+			stepped.Uint64nWithMCG()
+		}
+		jumped := NewPCG64(0, 0).Seed(12345, 67890, 1, 2).AdvanceMCG(d)
+
+		for i := 0; i < 10; i++ {
+			if got, want := jumped.Uint64nWithMCG(), stepped.Uint64nWithMCG(); got != want {
+				t.Fatalf("AdvanceMCG(%d) #%d: Uint64nWithMCG() = %#x; want %#x", d, i, got, want)
+			}
+		}
+	}
+
+	// jumps compose: AdvanceMCG(a) then AdvanceMCG(b) equals AdvanceMCG(a+b)
+	for _, tc := range []struct{ a, b uint64 }{{1 << 40, 12345}, {1 << 63, 1 << 62}, {math.MaxUint64 / 3, 7}} {
+		x := NewPCG64(42, 54).AdvanceMCG(tc.a).AdvanceMCG(tc.b)
+		y := NewPCG64(42, 54).AdvanceMCG(tc.a + tc.b)
+		xh, xl := x.State()
+		yh, yl := y.State()
+		if xh != yh || xl != yl {
+			t.Errorf("AdvanceMCG(%d) then AdvanceMCG(%d) = (%d, %d); want (%d, %d)", tc.a, tc.b, xh, xl, yh, yl)
+		}
+	}
+}
+
+func TestReferenceStream(t *testing.T) {
+	// golden values recorded from this package; they pin its own MCG stream
+	want := []uint64{
+		0xfb8689dba29a1a2a,
+		0x238bb96af872a224,
+		0x516dd1dd4ef12e64,
+		0xc5a5f4220dfec462,
+		0x1c0d2a6bf6ba26a4,
+		0x6fa4a38afaa270bb,
+		0x870cd96d97b5daf0,
+		0x2dde134295d26cc0,
+	}
+
+	got := ReferenceStream(1, 2, 3, 4, len(want))
+	for i, x := range want {
+		if got[i] != x {
+			t.Errorf("ReferenceStream #%d = %#x, want %#x", i, got[i], x)
+		}
+	}
+
+	p := NewPCG64(0, 0).Seed(1, 2, 3, 4)
+	for i, x := range ReferenceStream(1, 2, 3, 4, 100) {
+		if u := p.Uint64nWithMCG(); u != x {
+			t.Fatalf("ReferenceStream #%d = %#x, want %#x (Uint64nWithMCG)", i, x, u)
+		}
+	}
+
+	if res := ReferenceStream(1, 2, 3, 4, 0); len(res) != 0 {
+		t.Errorf("ReferenceStream(count=0) = %v; want empty", res)
+	}
+}
+
+func TestNewPCG64FromState(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	pcg.Seed(42, 54, 18, 27)
+	for i := 0; i < 100; i++ {
+		pcg.Uint64()
+	}
+
+	restored := NewPCG64FromState(pcg.hi.state, pcg.lo.state, pcg.hi.increment, pcg.lo.increment)
+	for i := 0; i < 100; i++ {
+		want := pcg.Uint64()
+		got := restored.Uint64()
+		if got != want {
+			t.Fatalf("#%d: NewPCG64FromState stream = %#x; want %#x", i, got, want)
+		}
+	}
+}
+
+func TestPCG64_StateAndIncrement(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	pcg.Seed(42, 54, 18, 27)
+	pcg.Uint64()
+
+	hiState, loState := pcg.State()
+	hiInc, loInc := pcg.Increment()
+	if h, l := pcg.State(); h != hiState || l != loState {
+		t.Errorf("State() changed between calls: (%d, %d) -> (%d, %d)", hiState, loState, h, l)
+	}
+
+	restored := NewPCG64FromState(hiState, loState, hiInc, loInc)
+	for i := 0; i < 10; i++ {
+		if got, want := restored.Uint64(), pcg.Uint64(); got != want {
+			t.Fatalf("#%d: restored stream = %#x; want %#x", i, got, want)
+		}
+	}
+}
+
+func TestPCG64_NextN(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 64, 1000} {
+		batch := NewPCG64(42, 54)
+		single := NewPCG64(42, 54)
+
+		res := batch.NextN(n)
+		if len(res) != n {
+			t.Fatalf("NextN(%d) len = %d; want %d", n, len(res), n)
+		}
+		for i, got := range res {
+			if want := single.Uint64(); got != want {
+				t.Fatalf("NextN(%d)[%d] = %#x; want %#x", n, i, got, want)
+			}
+		}
+		if got, want := batch.Uint64(), single.Uint64(); got != want {
+			t.Errorf("Uint64() after NextN(%d) = %#x; want %#x", n, got, want)
+		}
+	}
+}
+
+func TestPCG64_SeedDistinctStreams(t *testing.T) {
+	const top = uint64(1) << 63
+	mask := ^uint64(0) >> 1
 
 	tests := []struct {
-		delta           uint64
-		expectedStateHi uint64
-		expectedStateLo uint64
+		seq1, seq2 uint64
 	}{
-		{1, 9562276038148940761, 762819840426222678},
-		{10, 6073181909808593307, 4206663794773473040},
-		{100, 13320423970107457037, 15716292132216561082},
-		{1000, 2035794469121007023, 329538305516032724},
-		{10000, 8361027235883110657, 2874612980081388766},
+		{0, 0},
+		{0, top},
+		{top, 0},
+		{mask, mask},
+		{mask, ^uint64(0)},
+		{^uint64(0), ^uint64(0)},
+		{0, ^uint64(0)},
+		{42, 42 | top},
+		{42, ^uint64(42)},
+		{^uint64(42), 42},
 	}
 
-	for _, tt := range tests {
-		pcg.Retreat(tt.delta)
-		if pcg.hi.state != tt.expectedStateHi {
-			t.Errorf("Retreat(%d) hi state = %d; expected %d", tt.delta, pcg.hi.state, tt.expectedStateHi)
+	for _, tc := range tests {
+		p := NewPCG64(0, 0).Seed(1, 2, tc.seq1, tc.seq2)
+		if p.lo.increment == p.hi.increment {
+			t.Errorf("Seed(1, 2, %#x, %#x): both increments = %#x; want distinct", tc.seq1, tc.seq2, p.lo.increment)
 		}
-		if pcg.lo.state != tt.expectedStateLo {
-			t.Errorf("Retreat(%d) lo state = %d; expected %d", tt.delta, pcg.lo.state, tt.expectedStateLo)
+	}
+}
+
+func TestNewPCG64Auto(t *testing.T) {
+	a := NewPCG64Auto()
+	b := NewPCG64Auto()
+
+	same := true
+	for i := 0; i < 4; i++ {
+		if a.Uint64() != b.Uint64() {
+			same = false
 		}
 	}
+	if same {
+		t.Errorf("NewPCG64Auto() returned two generators with the same stream")
+	}
+}
+
+func TestPCG64_Seed2(t *testing.T) {
+	tests := []struct {
+		seed1, seed2 uint64
+	}{
+		{0, 0},
+		{42, 54},
+		{12345, 67890},
+		{^uint64(0), 1},
+	}
+
+	for _, tc := range tests {
+		got := NewPCG64(7, 8).Seed(1, 2, 3, 4).Seed2(tc.seed1, tc.seed2)
+		want := NewPCG64(tc.seed1, tc.seed2)
+		for i := 0; i < 100; i++ {
+			if g, w := got.Uint64(), want.Uint64(); g != w {
+				t.Fatalf("Seed2(%d, %d) #%d: Uint64() = %#x; want %#x", tc.seed1, tc.seed2, i, g, w)
+			}
+		}
+	}
+}
+
+func TestPCG64_SeedInt64(t *testing.T) {
+	a := NewPCG64(0, 0).SeedInt64(42)
+	b := NewPCG64(1, 2).SeedInt64(42)
+	c := NewPCG64(0, 0).SeedInt64(43)
+
+	diverged := false
+	for i := 0; i < 100; i++ {
+		va, vb, vc := a.Uint64(), b.Uint64(), c.Uint64()
+		if va != vb {
+			t.Fatalf("#%d: SeedInt64(42) streams differ: %#x != %#x", i, va, vb)
+		}
+		if va != vc {
+			diverged = true
+		}
+	}
+	if !diverged {
+		t.Errorf("SeedInt64(42) and SeedInt64(43) produced the same stream")
+	}
+}
+
+// countingSource is a deterministic rand.Source64 that returns consecutive integers.
+type countingSource struct {
+	next uint64
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.next++
+	return s.next
+}
+
+func (s *countingSource) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+func (s *countingSource) Seed(seed int64) { s.next = uint64(seed) }
+
+func TestPCG64_SeedFromSource(t *testing.T) {
+	a := NewPCG64(0, 0).SeedFromSource(&countingSource{})
+	b := NewPCG64(9, 9).SeedFromSource(&countingSource{})
+	want := NewPCG64(0, 0).Seed(1, 2, 3, 4)
+
+	for i := 0; i < 100; i++ {
+		va, vb, vw := a.Uint64(), b.Uint64(), want.Uint64()
+		if va != vw || vb != vw {
+			t.Fatalf("#%d: SeedFromSource streams = %#x, %#x; want %#x", i, va, vb, vw)
+		}
+	}
+
+	src := &countingSource{}
+	NewPCG64(0, 0).SeedFromSource(src)
+	if src.next != 4 {
+		t.Errorf("SeedFromSource pulled %d words; want 4", src.next)
+	}
+}
+
+func TestPCG64_Fingerprint(t *testing.T) {
+	a := NewPCG64(12345, 67890)
+	b := NewPCG64(12345, 67890)
+
+	// pinned so that changes to the hash are caught
+	if got, want := a.Fingerprint(), uint64(0x51b6a1595a9c7a29); got != want {
+		t.Errorf("Fingerprint() = %#x; want %#x", got, want)
+	}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("identically seeded generators have different fingerprints")
+	}
+	if a.Fingerprint() != a.Fingerprint() || a.Uint64() != b.Uint64() {
+		t.Errorf("Fingerprint() must not advance the generator")
+	}
+
+	a.Uint64()
+	if a.Fingerprint() == b.Fingerprint() {
+		t.Errorf("fingerprints did not diverge after a draw")
+	}
+
+	c := NewPCG64(0, 0).Seed(1, 2, 3, 4)
+	d := NewPCG64(0, 0).Seed(1, 2, 3, 5)
+	if c.Fingerprint() == d.Fingerprint() {
+		t.Errorf("generators differing only in increment have the same fingerprint")
+	}
+}
+
+func TestPCG64_Skip(t *testing.T) {
+	for _, n := range []uint64{0, 1, 2, 10, 1000} {
+		skipped := NewPCG64(0, 0).Seed(42, 54, 18, 27).Skip(n)
+		drawn := NewPCG64(0, 0).Seed(42, 54, 18, 27)
+		for i := uint64(0); i < n; i++ {
+			drawn.Uint64()
+		}
+		if skipped.hi.state != drawn.hi.state || skipped.lo.state != drawn.lo.state {
+			t.Errorf("Skip(%d) state = (%d, %d); want (%d, %d)",
+				n, skipped.hi.state, skipped.lo.state, drawn.hi.state, drawn.lo.state)
+		}
+	}
+}
+
+func TestPCG_Retreat(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	hiInc, loInc := pcg.Increment()
+
+	for _, delta := range []uint64{1, 10, 100, 1000, 10000} {
+		wantHi, wantLo := pcg.State()
+		pcg.Retreat(delta)
+
+		// stepping forward delta times from the retreated state must return to the start
+		hi, lo := pcg.State()
+		ref := NewPCG64FromState(hi, lo, hiInc, loInc)
+		for i := uint64(0); i < delta; i++ {
+			ref.Uint64()
+		}
+		if gotHi, gotLo := ref.State(); gotHi != wantHi || gotLo != wantLo {
+			t.Errorf("Retreat(%d) then %d Uint64 calls state = (%d, %d); want (%d, %d)", delta, delta, gotHi, gotLo, wantHi, wantLo)
+		}
+	}
+}
+
+func TestPCG64_AdvanceRetreat_Boundaries(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	hi, lo := pcg.State()
+
+	if gotHi, gotLo := pcg.Advance(0).State(); gotHi != hi || gotLo != lo {
+		t.Errorf("Advance(0) state = (%d, %d); want (%d, %d)", gotHi, gotLo, hi, lo)
+	}
+	if gotHi, gotLo := pcg.Retreat(0).State(); gotHi != hi || gotLo != lo {
+		t.Errorf("Retreat(0) state = (%d, %d); want (%d, %d)", gotHi, gotLo, hi, lo)
+	}
+
+	// ^MaxUint64 + 1 == 1, so this pair is a full trip around the period in both orders
+	if gotHi, gotLo := pcg.Advance(math.MaxUint64).Retreat(math.MaxUint64).State(); gotHi != hi || gotLo != lo {
+		t.Errorf("Advance(MaxUint64) then Retreat(MaxUint64) state = (%d, %d); want (%d, %d)", gotHi, gotLo, hi, lo)
+	}
+	if gotHi, gotLo := pcg.Retreat(math.MaxUint64).Advance(math.MaxUint64).State(); gotHi != hi || gotLo != lo {
+		t.Errorf("Retreat(MaxUint64) then Advance(MaxUint64) state = (%d, %d); want (%d, %d)", gotHi, gotLo, hi, lo)
+	}
+
+	// Retreat(MaxUint64) is one step forward
+	want := NewPCG64(12345, 67890).Uint64()
+	if got := NewPCG64(12345, 67890).Retreat(math.MaxUint64).Retreat(1).Uint64(); got != want {
+		t.Errorf("Retreat(MaxUint64).Retreat(1).Uint64() = %d; want %d", got, want)
+	}
+}
+
+func TestPCG64_Jump(t *testing.T) {
+	deltas := []int64{0, 1, -1, 7, -7, 1000, -1000, 1 << 40, -(1 << 40), math.MaxInt64, math.MinInt64}
+
+	for _, d := range deltas {
+		pcg := NewPCG64(0, 0).Seed(12345, 67890, 3, 4)
+		hi, lo := pcg.State()
+		pcg.Jump(d).Jump(-d)
+		if gotHi, gotLo := pcg.State(); gotHi != hi || gotLo != lo {
+			t.Errorf("Jump(%d) then Jump(%d) = (%d, %d); want (%d, %d)", d, -d, gotHi, gotLo, hi, lo)
+		}
+	}
+
+	// a positive jump lands where the same number of draws would
+	stepped := NewPCG64(0, 0).Seed(1, 2, 3, 4)
+	for i := 0; i < 100; i++ {
+		stepped.Uint64()
+	}
+	jumped := NewPCG64(0, 0).Seed(1, 2, 3, 4).Jump(100)
+	if got, want := jumped.Uint64(), stepped.Uint64(); got != want {
+		t.Errorf("Uint64() after Jump(100) = %#x; want %#x", got, want)
+	}
+	if got, want := jumped.Jump(-1).Uint64(), stepped.Jump(-1).Uint64(); got != want {
+		t.Errorf("Uint64() after Jump(-1) = %#x; want %#x", got, want)
+	}
+
+	for _, d := range []uint64{1, 10, 12345} {
+		pcg := NewPCG64(0, 0).Seed(5, 6, 7, 8)
+		hi, lo := pcg.State()
+		pcg.Advance(d).Retreat(d)
+		if gotHi, gotLo := pcg.State(); gotHi != hi || gotLo != lo {
+			t.Errorf("Advance(%d) then Retreat(%d) = (%d, %d); want (%d, %d)", d, d, gotHi, gotLo, hi, lo)
+		}
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	want := new(big.Int).Lsh(big.NewInt(1), 64)
+
+	if got := NewPCG32().Seed(42, 54).Period(); got.Cmp(want) != 0 {
+		t.Errorf("PCG32.Period() = %v; want 2^64 = %v", got, want)
+	}
+
+	pcg := NewPCG64(42, 54)
+	got := pcg.Period()
+	if got.Cmp(want) != 0 {
+		t.Errorf("PCG64.Period() = %v; want 2^64 = %v", got, want)
+	}
+	got.SetInt64(0)
+	if pcg.Period().Cmp(want) != 0 {
+		t.Errorf("modifying the result of Period() changed later results")
+	}
+
+	// half the period away the state differs, a full period (two halves) comes back around
+	hi, lo := pcg.State()
+	pcg.Advance(1 << 63)
+	if h, l := pcg.State(); h == hi || l == lo {
+		t.Errorf("state repeated after 2^63 steps; want a period of 2^64")
+	}
+	pcg.Advance(1 << 63)
+	if h, l := pcg.State(); h != hi || l != lo {
+		t.Errorf("state after 2^64 steps = (%d, %d); want (%d, %d)", h, l, hi, lo)
+	}
 }
 
 func Test_ExamplePCG64_Shuffle(t *testing.T) {
@@ -162,6 +720,40 @@ func Test_ExamplePCG64_Shuffle(t *testing.T) {
 	}
 }
 
+func TestPCG64_PermInto(t *testing.T) {
+	a := NewPCG64(42, 54)
+	b := NewPCG64(42, 54)
+
+	dst := make([]int, 50)
+	for _, n := range []int{0, 1, 5, 50} {
+		dst = dst[:n]
+		for i := range dst {
+			dst[i] = -1
+		}
+		a.PermInto(dst)
+		if want := b.Perm(n); !isArrayEqual(dst, want) {
+			t.Errorf("PermInto(%d) = %v; want %v (Perm)", n, dst, want)
+		}
+	}
+}
+
+func TestPCG64_Perm64(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	for _, n := range []int64{0, 1, 2, 10, 1000, 100000} {
+		perm := pcg.Perm64(n)
+		if int64(len(perm)) != n {
+			t.Fatalf("Perm64(%d) len = %d; want %d", n, len(perm), n)
+		}
+		seen := make([]bool, n)
+		for _, v := range perm {
+			if v < 0 || v >= n || seen[v] {
+				t.Fatalf("Perm64(%d) is not a permutation: bad or repeated value %d", n, v)
+			}
+			seen[v] = true
+		}
+	}
+}
+
 func TestFloat64(t *testing.T) {
 	pcg := NewPCG64(42, 54)
 	for i := 0; i < 1000; i++ {
@@ -182,6 +774,74 @@ func TestFloat64Full(t *testing.T) {
 	}
 }
 
+func TestFloat64Full_Mean(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	n := 100000
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += pcg.Float64Full()
+	}
+	mean := sum / float64(n)
+	if math.Abs(mean-0.5) > 0.01 {
+		t.Errorf("Float64Full() mean = %f; want ~0.5", mean)
+	}
+}
+
+func TestFloat64MathRandCompat(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	sum := 0.0
+	const n = 1000000
+	for i := 0; i < n; i++ {
+		val := pcg.Float64MathRandCompat()
+		if val < 0.0 || val >= 1.0 {
+			t.Fatalf("Float64MathRandCompat() = %v; want a value in [0, 1)", val)
+		}
+		sum += val
+	}
+	if mean := sum / n; math.Abs(mean-0.5) > 0.01 {
+		t.Errorf("Float64MathRandCompat() mean = %f; want ~0.5", mean)
+	}
+
+	// the same transform as math/rand applied to the same Int63 stream
+	a := NewPCG64(1, 2)
+	b := NewPCG64(1, 2)
+	for i := 0; i < 100; i++ {
+		want := float64(b.Uint63()&(1<<53-1)) / (1 << 53)
+		if got := a.Float64MathRandCompat(); got != want {
+			t.Fatalf("#%d: Float64MathRandCompat() = %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestFloat64OpenIntervals(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	for i := 0; i < 2000000; i++ {
+		if v := pcg.Float64OpenOpen(); v <= 0 || v >= 1 {
+			t.Fatalf("Float64OpenOpen() = %v; want a value in (0, 1)", v)
+		}
+		if v := pcg.Float64OpenClosed(); v <= 0 || v > 1 {
+			t.Fatalf("Float64OpenClosed() = %v; want a value in (0, 1]", v)
+		}
+	}
+
+	// the extreme draws map to the interval edges exactly
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"openOpen(0)", openOpen(0), 0x1p-53},
+		{"openOpen(MaxUint64)", openOpen(math.MaxUint64), 1 - 0x1p-53},
+		{"openClosed(0)", openClosed(0), 0x1p-53},
+		{"openClosed(MaxUint64)", openClosed(math.MaxUint64), 1},
+	}
+	for _, tc := range tests {
+		if tc.got != tc.want {
+			t.Errorf("%s = %v; want %v", tc.name, tc.got, tc.want)
+		}
+	}
+}
+
 func TestPCG64Read(t *testing.T) {
 	now := uint64(time.Now().UnixNano())
 	testSizes := []int{16, 32, 48, 64, 100, 1023, 2048}
@@ -210,6 +870,34 @@ func TestPCG64Read(t *testing.T) {
 	}
 }
 
+func TestRead_NilAndEmpty(t *testing.T) {
+	for _, buf := range [][]byte{nil, {}} {
+		p32 := NewPCG32().Seed(42, 54)
+		state := p32.state
+		if n, err := p32.Read(buf); n != 0 || err != nil {
+			t.Errorf("PCG32.Read(%#v) = %d, %v; want 0, nil", buf, n, err)
+		}
+		if n, err := p32.ReadFast(buf); n != 0 || err != nil {
+			t.Errorf("PCG32.ReadFast(%#v) = %d, %v; want 0, nil", buf, n, err)
+		}
+		if p32.state != state {
+			t.Errorf("PCG32 reads of %#v advanced the generator", buf)
+		}
+
+		p64 := NewPCG64(42, 54)
+		hi, lo := p64.State()
+		if n, err := p64.Read(buf); n != 0 || err != nil {
+			t.Errorf("PCG64.Read(%#v) = %d, %v; want 0, nil", buf, n, err)
+		}
+		if n, err := NewReader(p64).Read(buf); n != 0 || err != nil {
+			t.Errorf("Reader.Read(%#v) = %d, %v; want 0, nil", buf, n, err)
+		}
+		if h, l := p64.State(); h != hi || l != lo {
+			t.Errorf("PCG64 reads of %#v advanced the generator", buf)
+		}
+	}
+}
+
 func TestPCG64ReadEdgeCases(t *testing.T) {
 	now := uint64(time.Now().UnixNano())
 	edgeSizes := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 15, 17}
@@ -238,6 +926,63 @@ func TestPCG64ReadEdgeCases(t *testing.T) {
 	}
 }
 
+func TestPCG64_ReadAligned(t *testing.T) {
+	for _, size := range []int{16, 32, 48, 4096} {
+		got := make([]byte, size)
+		NewPCG64(42, 54).Read(got)
+
+		// the general loop, forced by enabling fork detection
+		want := make([]byte, size)
+		NewPCG64(42, 54).EnableForkDetection().Read(want)
+		if !bytes.Equal(got, want) {
+			t.Errorf("Read(%d bytes) fast path differs from the general loop", size)
+		}
+
+		for i, v := range NewPCG64(42, 54).NextN(size / 8) {
+			if w := binary.LittleEndian.Uint64(got[8*i:]); w != v {
+				t.Fatalf("Read(%d bytes) word %d = %#x; want %#x", size, i, w, v)
+			}
+		}
+	}
+
+	// the generator must be left where the general loop leaves it
+	fast, slow := NewPCG64(1, 2), NewPCG64(1, 2).EnableForkDetection()
+	fast.Read(make([]byte, 160))
+	slow.Read(make([]byte, 160))
+	if fast.Uint64() != slow.Uint64() {
+		t.Errorf("Uint64() after an aligned Read differs from the general loop")
+	}
+}
+
+func TestPCG64_Blocks(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+
+	want16 := [16]byte{
+		0x50, 0x01, 0xe0, 0x44, 0x97, 0xbf, 0x2e, 0xd2,
+		0xe3, 0x75, 0x71, 0xe1, 0x2c, 0x87, 0xb4, 0x26,
+	}
+	want32 := [32]byte{
+		0xd6, 0x1b, 0x1b, 0x5a, 0xc8, 0xe8, 0xe3, 0x2b,
+		0xff, 0x26, 0x88, 0xaf, 0xdf, 0x36, 0x81, 0xe5,
+		0x3a, 0x18, 0xf8, 0x74, 0x82, 0xff, 0x0a, 0x17,
+		0x1f, 0x05, 0xe1, 0xf3, 0xc9, 0xcb, 0x2c, 0x83,
+	}
+
+	if got := pcg.Block16(); got != want16 {
+		t.Errorf("Block16() = %#v; want %#v", got, want16)
+	}
+	if got := pcg.Block32(); got != want32 {
+		t.Errorf("Block32() = %#v; want %#v", got, want32)
+	}
+
+	// blocks must match Read over the same stream
+	buf := make([]byte, 48)
+	NewPCG64(12345, 67890).Read(buf)
+	if string(buf[:16]) != string(want16[:]) || string(buf[16:]) != string(want32[:]) {
+		t.Errorf("Read() = %#v; want the bytes of Block16() followed by Block32()", buf)
+	}
+}
+
 func TestPCG_MarshalBinaryUnsafe(t *testing.T) {
 	pcg := NewPCG64(12345, 67890)
 	b, err := pcg.MarshalBinaryUnsafe()
@@ -250,6 +995,383 @@ func TestPCG_MarshalBinaryUnsafe(t *testing.T) {
 	if string(b[:4]) != "pcg:" {
 		t.Errorf("MarshalBinaryUnsafe() b[:4] = %s; want 'pcg:'", string(b[:4]))
 	}
+
+	safe, _ := pcg.MarshalBinaryPCG64()
+	if !bytes.Equal(b, safe[:20]) {
+		t.Errorf("MarshalBinaryUnsafe() = %x; want the states of MarshalBinaryPCG64() %x", b, safe[:20])
+	}
+
+	restored := NewPCG64(1, 2)
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary(MarshalBinaryUnsafe()) error = %v; want nil", err)
+	}
+	for i := 0; i < 10; i++ {
+		if got, want := restored.Uint64(), pcg.Uint64(); got != want {
+			t.Fatalf("#%d: restored Uint64() = %#x; want %#x", i, got, want)
+		}
+	}
+}
+
+var sinkUint64s []uint64
+
+func BenchmarkPCG64_NextN(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	for i := 0; i < b.N; i++ {
+		sinkUint64s = pcg.NextN(1024)
+	}
+}
+
+func BenchmarkPCG64_FillUint64n(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	dst := make([]uint64, 1024)
+	for i := 0; i < b.N; i++ {
+		pcg.FillUint64n(dst, benchBound64)
+	}
+}
+
+func BenchmarkPCG64_Uint64nLoop(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	dst := make([]uint64, 1024)
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = pcg.Uint64n(benchBound64)
+		}
+	}
+}
+
+func BenchmarkPCG64_Uint64Loop(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	for i := 0; i < b.N; i++ {
+		res := make([]uint64, 1024)
+		for j := range res {
+			res[j] = pcg.Uint64()
+		}
+		sinkUint64s = res
+	}
+}
+
+func TestPCG64_AppendBinary(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	pcg.Uint64()
+
+	want, _ := pcg.MarshalBinaryPCG64()
+	prefix := []byte("header")
+	got, err := pcg.AppendBinary(prefix)
+	if err != nil {
+		t.Fatalf("AppendBinary() error = %v; want nil", err)
+	}
+	if string(got[:len(prefix)]) != "header" || string(got[len(prefix):]) != string(want) {
+		t.Errorf("AppendBinary() = %v; want %q followed by %v", got, prefix, want)
+	}
+
+	restored := NewPCG64(0, 0)
+	if err := restored.UnmarshalBinary(got[len(prefix):]); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v; want nil", err)
+	}
+	if restored.hi.state != pcg.hi.state || restored.lo.state != pcg.lo.state {
+		t.Errorf("UnmarshalBinary(AppendBinary()) did not restore the state")
+	}
+}
+
+func TestPCG64_MarshalBinary_PendingSpare(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	pcg.NormFloat64() // leaves the second normal of the pair cached
+
+	b, err := pcg.MarshalBinaryPCG64()
+	if err != nil {
+		t.Fatalf("MarshalBinaryPCG64() error = %v; want nil", err)
+	}
+	if len(b) != 29 || b[20] != 1 {
+		t.Fatalf("MarshalBinaryPCG64() mid-pair = %v; want 29 bytes with the spare flag set", b)
+	}
+
+	restored := NewPCG64(0, 0)
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v; want nil", err)
+	}
+	for i := 0; i < 11; i++ {
+		if got, want := restored.NormFloat64(), pcg.NormFloat64(); got != want {
+			t.Fatalf("#%d: restored NormFloat64() = %v; want %v", i, got, want)
+		}
+	}
+
+	// an odd number of draws later no spare is pending again
+	b, _ = pcg.MarshalBinaryPCG64()
+	if len(b) != 21 || b[20] != 0 {
+		t.Errorf("MarshalBinaryPCG64() between pairs = %v; want 21 bytes with the spare flag clear", b)
+	}
+	if err := restored.UnmarshalBinary(b); err != nil || restored.hasSpare {
+		t.Errorf("UnmarshalBinary() = %v, hasSpare = %v; want nil, false", err, restored.hasSpare)
+	}
+}
+
+func TestPCG64_Float64Antithetic(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	const pairs = 50000
+	var sum float64
+	for i := 0; i < pairs; i++ {
+		u, v := pcg.Float64Antithetic(), pcg.Float64Antithetic()
+		if u <= 0 || u >= 1 || v <= 0 || v >= 1 {
+			t.Fatalf("pair %d: Float64Antithetic() = %v, %v; want values in (0, 1)", i, u, v)
+		}
+		if math.Abs(u+v-1) > 1e-15 {
+			t.Fatalf("pair %d: %v + %v = %v; want 1", i, u, v, u+v)
+		}
+		sum += u
+	}
+	// pairs average to exactly 1/2, so check the fresh draws on their own
+	if mean := sum / pairs; math.Abs(mean-0.5) > 0.01 {
+		t.Errorf("Float64Antithetic() mean of fresh draws = %f; want ~0.5", mean)
+	}
+
+	// the first half of a pair is an ordinary Float64Full draw
+	a, b := NewPCG64(7, 8), NewPCG64(7, 8)
+	if got, want := a.Float64Antithetic(), b.Float64Full(); got != want {
+		t.Errorf("Float64Antithetic() = %v; want Float64Full() = %v", got, want)
+	}
+	// a zero state pair makes the first Float64Full draws exactly 0, and they are skipped
+	zero := NewPCG64FromState(0, 0, 1, 3)
+	ref := NewPCG64FromState(0, 0, 1, 3)
+	if got := ref.Float64Full(); got != 0 {
+		t.Fatalf("Float64Full() from a zero state = %v; want 0", got)
+	}
+	want := ref.Float64Full()
+	for want == 0 {
+		want = ref.Float64Full()
+	}
+	if got := zero.Float64Antithetic(); got != want {
+		t.Errorf("Float64Antithetic() after a zero draw = %v; want the next draw %v", got, want)
+	}
+	if got := zero.Float64Antithetic(); got != 1-want || got >= 1 {
+		t.Errorf("Float64Antithetic() complement = %v; want %v", got, 1-want)
+	}
+}
+
+func TestPCG64_MarshalBinary_PendingAntithetic(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	pcg.NormFloat64()
+	pcg.Float64Antithetic()
+
+	b, err := pcg.MarshalBinaryPCG64()
+	if err != nil {
+		t.Fatalf("MarshalBinaryPCG64() error = %v; want nil", err)
+	}
+	if len(b) != 37 || b[20] != flagSpare|flagAntithetic {
+		t.Fatalf("MarshalBinaryPCG64() = %v; want 37 bytes with both flags set", b)
+	}
+
+	restored := NewPCG64(0, 0)
+	if err := restored.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v; want nil", err)
+	}
+	for i := 0; i < 5; i++ {
+		if got, want := restored.Float64Antithetic(), pcg.Float64Antithetic(); got != want {
+			t.Fatalf("#%d: restored Float64Antithetic() = %v; want %v", i, got, want)
+		}
+		if got, want := restored.NormFloat64(), pcg.NormFloat64(); got != want {
+			t.Fatalf("#%d: restored NormFloat64() = %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestNewPCG64Streams(t *testing.T) {
+	const n = 64
+	streams := NewPCG64Streams(42, n)
+	if len(streams) != n {
+		t.Fatalf("len(NewPCG64Streams(42, %d)) = %d; want %d", n, len(streams), n)
+	}
+
+	firsts := make([]uint64, n)
+	seen := make(map[uint64]int, n)
+	increments := make(map[uint64]bool, 2*n)
+	for i, p := range streams {
+		for _, inc := range []uint64{p.hi.increment, p.lo.increment} {
+			if increments[inc] {
+				t.Errorf("stream %d reuses increment %#x", i, inc)
+			}
+			increments[inc] = true
+		}
+		firsts[i] = p.Uint64()
+		if j, ok := seen[firsts[i]]; ok {
+			t.Errorf("streams %d and %d share the first output %#x", j, i, firsts[i])
+		}
+		seen[firsts[i]] = i
+	}
+
+	for i, p := range NewPCG64Streams(42, n) {
+		if got := p.Uint64(); got != firsts[i] {
+			t.Errorf("stream %d: first Uint64() = %#x; want %#x on a rebuilt set", i, got, firsts[i])
+		}
+	}
+
+	if got := NewPCG64Streams(42, 0); len(got) != 0 {
+		t.Errorf("NewPCG64Streams(42, 0) = %v; want empty", got)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("NewPCG64Streams(42, -1) did not panic")
+		}
+	}()
+	NewPCG64Streams(42, -1)
+}
+
+func TestPCG64_Consumed(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	if got := pcg.Consumed(); got != 0 {
+		t.Fatalf("Consumed() after seeding = %d; want 0", got)
+	}
+
+	for i := 0; i < 5; i++ {
+		pcg.Uint64()
+	}
+	if got := pcg.Consumed(); got != 5 {
+		t.Errorf("Consumed() after 5 Uint64 calls = %d; want 5", got)
+	}
+
+	// 32 bytes take the aligned path, 3 bytes still use a whole draw
+	pcg.Read(make([]byte, 32))
+	pcg.Read(make([]byte, 3))
+	if got := pcg.Consumed(); got != 10 {
+		t.Errorf("Consumed() after Read(32 bytes) and Read(3 bytes) = %d; want 10", got)
+	}
+
+	// Shuffle makes at least one draw per swap; replaying the count lands on the same state
+	pcg.Shuffle(10, func(i, j int) {})
+	n := pcg.Consumed()
+	if n < 10+9 {
+		t.Errorf("Consumed() after Shuffle(10) = %d; want at least 19", n)
+	}
+	replay := NewPCG64(42, 54)
+	for i := uint64(0); i < n; i++ {
+		replay.Uint64()
+	}
+	if got, want := replay.Fingerprint(), pcg.Fingerprint(); got != want {
+		t.Errorf("replaying %d Uint64 calls reached a different state", n)
+	}
+
+	// repositioning is not drawing
+	pcg.Advance(100).Retreat(1).Jump(-5)
+	if got := pcg.Consumed(); got != n {
+		t.Errorf("Consumed() after Advance, Retreat and Jump = %d; want %d", got, n)
+	}
+
+	pcg.Skip(7)
+	pcg.NextN(4)
+	pcg.Uint64nWithMCG()
+	pcg.Float64MCG()
+	n += 7 + 4 + 2
+	if got := pcg.Consumed(); got != n {
+		t.Errorf("Consumed() after Skip(7), NextN(4) and two MCG draws = %d; want %d", got, n)
+	}
+
+	// the plain encoding resets the count, MarshalBinaryWithConsumed keeps it
+	plain, _ := pcg.MarshalBinaryPCG64()
+	withCount, err := pcg.MarshalBinaryWithConsumed()
+	if err != nil {
+		t.Fatalf("MarshalBinaryWithConsumed() error = %v; want nil", err)
+	}
+	restored := NewPCG64(42, 54)
+	if err := restored.UnmarshalBinary(plain); err != nil || restored.Consumed() != 0 {
+		t.Errorf("UnmarshalBinary(plain) = %v, Consumed() = %d; want nil, 0", err, restored.Consumed())
+	}
+	if err := restored.UnmarshalBinary(withCount); err != nil || restored.Consumed() != n {
+		t.Errorf("UnmarshalBinary(with count) = %v, Consumed() = %d; want nil, %d", err, restored.Consumed(), n)
+	}
+	if got, want := restored.Uint64(), pcg.Uint64(); got != want {
+		t.Errorf("restored Uint64() = %d; want %d", got, want)
+	}
+
+	pcg.Seed2(1, 2)
+	if got := pcg.Consumed(); got != 0 {
+		t.Errorf("Consumed() after Seed2 = %d; want 0", got)
+	}
+}
+
+func TestPCG64_Consumed_RawState(t *testing.T) {
+	// an even increment is legal for NewPCG64FromState and must not break the count
+	p := NewPCG64FromState(1, 2, 4, 6)
+	p.Uint64()
+	p.Read(make([]byte, 16))
+	if got := p.Consumed(); got != 3 {
+		t.Errorf("Consumed() with an even increment = %d; want 3", got)
+	}
+
+	guarded := NewPCG64(42, 54).EnableForkDetection()
+	guarded.Uint64()
+	guarded.Read(make([]byte, 16))
+	if got := guarded.Consumed(); got != 3 {
+		t.Errorf("Consumed() with fork detection = %d; want 3", got)
+	}
+}
+
+func TestPCG64_BinaryMarshalerRoundTrip(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	pcg.NormFloat64()
+
+	var m encoding.BinaryMarshaler = pcg
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v; want nil", err)
+	}
+	if want, _ := pcg.MarshalBinaryPCG64(); !bytes.Equal(b, want) {
+		t.Errorf("MarshalBinary() = %v; want %v", b, want)
+	}
+
+	var u encoding.BinaryUnmarshaler = NewPCG64(0, 0)
+	if err := u.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary() error = %v; want nil", err)
+	}
+	restored := u.(*PCG64)
+
+	// gob picks up the same methods
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pcg); err != nil {
+		t.Fatalf("gob Encode() error = %v; want nil", err)
+	}
+	decoded := NewPCG64(0, 0)
+	if err := gob.NewDecoder(&buf).Decode(decoded); err != nil {
+		t.Fatalf("gob Decode() error = %v; want nil", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		want := pcg.NormFloat64()
+		if got := restored.NormFloat64(); got != want {
+			t.Fatalf("#%d: restored NormFloat64() = %v; want %v", i, got, want)
+		}
+		if got := decoded.NormFloat64(); got != want {
+			t.Fatalf("#%d: gob decoded NormFloat64() = %v; want %v", i, got, want)
+		}
+	}
+}
+
+func TestPCG64_UnmarshalBinary_Invalid(t *testing.T) {
+	valid, _ := NewPCG64(1, 2).MarshalBinaryPCG64()
+
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{"empty", nil},
+		{"bad prefix", append([]byte("pgc:"), valid[4:]...)},
+		{"truncated", valid[:19]},
+		{"unknown flag", append(valid[:20:20], 4)},
+		{"antithetic flag without value", append(valid[:20:20], 2)},
+		{"flag without spare", append(valid[:20:20], 1)},
+		{"spare without flag", append(valid[:21:21], make([]byte, 8)...)},
+	}
+
+	for _, tc := range tests {
+		if err := NewPCG64(0, 0).UnmarshalBinary(tc.b); err != errUnmarshalPCG {
+			t.Errorf("UnmarshalBinary(%s) error = %v; want %v", tc.name, err, errUnmarshalPCG)
+		}
+	}
+
+	// the 20-byte encoding without a flag byte is still accepted
+	legacy := NewPCG64(0, 0)
+	if err := legacy.UnmarshalBinary(valid[:20]); err != nil {
+		t.Errorf("UnmarshalBinary(20-byte encoding) error = %v; want nil", err)
+	}
 }
 
 func BenchmarkPCG_Seed(b *testing.B) {
@@ -259,6 +1381,25 @@ func BenchmarkPCG_Seed(b *testing.B) {
 	}
 }
 
+var sinkInts []int
+
+func BenchmarkPCG64_Perm(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		sinkInts = pcg.Perm(100)
+	}
+}
+
+func BenchmarkPCG64_PermInto(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	dst := make([]int, 100)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pcg.PermInto(dst)
+	}
+}
+
 func BenchmarkPCG_MarshalBinary(b *testing.B) {
 	pcg := NewPCG64(12345, 67890)
 	for i := 0; i < b.N; i++ {
@@ -266,6 +1407,15 @@ func BenchmarkPCG_MarshalBinary(b *testing.B) {
 	}
 }
 
+func BenchmarkPCG_AppendBinary(b *testing.B) {
+	pcg := NewPCG64(12345, 67890)
+	buf := make([]byte, 0, 37)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = pcg.AppendBinary(buf[:0])
+	}
+}
+
 func BenchmarkPCG_MarshalBinary_Unsafe(b *testing.B) {
 	pcg := NewPCG64(12345, 67890)
 	for i := 0; i < b.N; i++ {
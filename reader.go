@@ -0,0 +1,72 @@
+package pcg
+
+import "errors"
+
+// A Reader is an io.Reader over the bytes of consecutive PCG64 Uint64 draws in little-endian order.
+// Unlike PCG64.Read, which drops the unused bytes of the last draw of every call, a Reader keeps
+// them for the next call, so the byte stream does not depend on how reads are split up.
+type Reader struct {
+	p    *PCG64
+	word uint64 // unread bytes of the current draw, lowest byte next
+	left int    // number of unread bytes in word
+}
+
+var errNegativeDiscard = errors.New("pcg: negative Discard count")
+
+// NewReader returns a Reader drawing from p.
+func NewReader(p *PCG64) *Reader {
+	return &Reader{p: p}
+}
+
+// Read fills buf with the next len(buf) bytes of the stream. It always returns len(buf), nil.
+// Reads whose length is a multiple of 8 made while no bytes are buffered produce the same
+// bytes as PCG64.Read.
+func (r *Reader) Read(buf []byte) (int, error) {
+	i := 0
+	for ; i < len(buf) && r.left > 0; i++ {
+		buf[i] = byte(r.word)
+		r.word >>= 8
+		r.left--
+	}
+	for ; i+8 <= len(buf); i += 8 {
+		v := r.p.Uint64()
+		for k := 0; k < 8; k++ {
+			buf[i+k] = byte(v >> (8 * k))
+		}
+	}
+	if i < len(buf) {
+		r.word, r.left = r.p.Uint64(), 8
+		for ; i < len(buf); i++ {
+			buf[i] = byte(r.word)
+			r.word >>= 8
+			r.left--
+		}
+	}
+	return len(buf), nil
+}
+
+// Discard skips the next n bytes of the stream without producing them, for example to align
+// the stream to a boundary, and returns the number of bytes discarded. Whole draws are skipped
+// with Advance, so the generator ends up exactly where reading n bytes would leave it.
+// Like bufio.Reader.Discard it returns an error, and discards nothing, if n is negative.
+func (r *Reader) Discard(n int) (int, error) {
+	if n < 0 {
+		return 0, errNegativeDiscard
+	}
+
+	rest := n
+	if rest <= r.left {
+		r.word >>= 8 * uint(rest)
+		r.left -= rest
+		return n, nil
+	}
+	rest -= r.left
+	r.word, r.left = 0, 0
+
+	r.p.Advance(uint64(rest / 8))
+	if tail := rest % 8; tail > 0 {
+		r.word = r.p.Uint64() >> (8 * uint(tail))
+		r.left = 8 - tail
+	}
+	return n, nil
+}
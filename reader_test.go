@@ -0,0 +1,56 @@
+package pcg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReader_MatchesRead(t *testing.T) {
+	want := make([]byte, 4096)
+	NewPCG64(42, 54).Read(want)
+
+	r := NewReader(NewPCG64(42, 54))
+	got := make([]byte, 0, len(want))
+	// uneven chunk sizes must still yield one continuous stream
+	for _, n := range []int{1, 7, 8, 3, 100, 13, 0, 3964} {
+		chunk := make([]byte, n)
+		if m, err := r.Read(chunk); m != n || err != nil {
+			t.Fatalf("Read(%d bytes) = %d, %v; want %d, nil", n, m, err, n)
+		}
+		got = append(got, chunk...)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("chunked Reader output differs from PCG64.Read")
+	}
+}
+
+func TestReader_Discard(t *testing.T) {
+	for _, pre := range []int{0, 3, 8} {
+		for _, k := range []int{0, 1, 5, 8, 9, 64, 1001} {
+			// reference: consume pre+k bytes explicitly
+			ref := NewReader(NewPCG64(12345, 67890))
+			ref.Read(make([]byte, pre+k))
+			want := make([]byte, 37)
+			ref.Read(want)
+
+			src := NewPCG64(12345, 67890)
+			r := NewReader(src)
+			r.Read(make([]byte, pre))
+			if n, err := r.Discard(k); n != k || err != nil {
+				t.Fatalf("Discard(%d) = %d, %v; want %d, nil", k, n, err, k)
+			}
+			got := make([]byte, 37)
+			r.Read(got)
+			if !bytes.Equal(got, want) {
+				t.Errorf("after Read(%d) and Discard(%d): Read = %x; want %x", pre, k, got, want)
+			}
+			if src.hi.state != ref.p.hi.state || src.lo.state != ref.p.lo.state {
+				t.Errorf("after Read(%d) and Discard(%d): generator state differs from explicit reads", pre, k)
+			}
+		}
+	}
+
+	if n, err := NewReader(NewPCG64(1, 2)).Discard(-1); n != 0 || err == nil {
+		t.Errorf("Discard(-1) = %d, %v; want 0 and an error", n, err)
+	}
+}
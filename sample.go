@@ -0,0 +1,387 @@
+package pcg
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// PartialShuffle performs only the first k iterations of a Fisher-Yates shuffle over n elements.
+// Afterwards the first k positions hold a uniform random sample of all n elements in random order,
+// while the remaining positions are left partially shuffled. It costs O(k) instead of O(n).
+// It panics if n < 0, k < 0 or k > n.
+func (p *PCG64) PartialShuffle(n, k int, swap func(i, j int)) {
+	if n < 0 || k < 0 || k > n {
+		panic("invalid argument to PartialShuffle")
+	}
+	for i := 0; i < k && i < n-1; i++ {
+		j := i + int(p.Uint64n(uint64(n-i)))
+		swap(i, j)
+	}
+}
+
+// ShuffleDeterministic is Shuffle with an explicit check on n. The swap sequence of Shuffle
+// already depends only on the generator state and n, never on the platform (PCG32.Shuffle,
+// by contrast, draws 32-bit indices); this name makes that requirement visible at call sites.
+// It panics if n < 0.
+func (p *PCG64) ShuffleDeterministic(n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("invalid argument to ShuffleDeterministic")
+	}
+	p.Shuffle(n, swap)
+}
+
+// KPermutation returns an ordered sample of k distinct values from [0, n).
+// Order matters: every one of the n!/(n-k)! ordered k-tuples is equally likely.
+// It runs a partial Fisher-Yates shuffle that stops after k swaps. The shuffle is sparse:
+// only positions whose value has been swapped away from the identity are recorded, so time
+// and memory are O(k) however large n is.
+// It panics if n < 0, k < 0 or k > n.
+func (p *PCG64) KPermutation(n, k int) []int {
+	if n < 0 || k < 0 || k > n {
+		panic("invalid argument to KPermutation")
+	}
+
+	res := make([]int, k)
+	displaced := make(map[int]int, k) // position -> value, where the value is not the position
+	for i := range res {
+		j := i + int(p.Uint64n(uint64(n-i)))
+		vi, ok := displaced[i]
+		if !ok {
+			vi = i
+		}
+		vj, ok := displaced[j]
+		if !ok {
+			vj = j
+		}
+		// position i is final; later iterations only read positions above it
+		res[i] = vj
+		displaced[j] = vi
+	}
+	return res
+}
+
+// PermutationPair returns a random permutation of [0, n) together with its inverse,
+// so that inverse[perm[i]] == i for every i. perm is the same permutation Perm(n) would return.
+// It panics if n < 0.
+func (p *PCG64) PermutationPair(n int) (perm, inverse []int) {
+	if n < 0 {
+		panic("invalid argument to PermutationPair")
+	}
+	perm = p.Perm(n)
+	inverse = make([]int, n)
+	for i, v := range perm {
+		inverse[v] = i
+	}
+	return perm, inverse
+}
+
+// ShuffleAny shuffles the elements of slice in place, for code that only has the slice as an
+// interface{} value. It uses reflect.Swapper and produces the same order as Shuffle over the
+// same length. It panics if slice is not a slice.
+func (p *PCG64) ShuffleAny(slice interface{}) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice {
+		panic(fmt.Sprintf("pcg: ShuffleAny called with non-slice type %T", slice))
+	}
+	p.Shuffle(v.Len(), reflect.Swapper(slice))
+}
+
+// WeightedIndex returns a single index i drawn with probability weights[i] / sum(weights).
+// It makes one pass to sum the weights and one linear scan of the running sum against a
+// Float64Full()*total draw, with no setup to amortize, so it suits one-off picks.
+// For repeated sampling from the same weights, a Categorical built from their logarithms
+// with NewCategoricalLogits avoids rescanning them.
+// Indices with zero weight are never returned.
+// It panics if any weight is negative, NaN or infinite, or if the weights sum to zero.
+func (p *PCG64) WeightedIndex(weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid argument to WeightedIndex")
+		}
+		total += w
+	}
+	if !(total > 0) || math.IsInf(total, 1) {
+		panic("invalid argument to WeightedIndex")
+	}
+
+	u := p.Float64Full() * total
+	last := 0
+	cum := 0.0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		cum += w
+		if u < cum {
+			return i
+		}
+		last = i
+	}
+	return last // rounding left u at or just above the final running sum
+}
+
+// WeightedSample draws k distinct indices from weights without replacement, where each draw picks
+// an index with probability proportional to its weight among the ones not yet drawn.
+// It uses the Efraimidis-Spirakis A-Res scheme: every index gets the key U^(1/w) and the k largest
+// keys win, returned in descending key order (the order in which they would have been drawn).
+// Indices with zero weight are only returned after all positive weights are exhausted.
+// It panics if any weight is negative or k is not in [0, len(weights)].
+func (p *PCG64) WeightedSample(weights []float64, k int) []int {
+	if k < 0 || k > len(weights) {
+		panic("invalid argument to WeightedSample")
+	}
+
+	// log(U)/w preserves the order of U^(1/w) without underflowing for small weights.
+	keys := make([]float64, len(weights))
+	idx := make([]int, len(weights))
+	for i, w := range weights {
+		if !(w >= 0) {
+			panic("invalid argument to WeightedSample")
+		}
+		idx[i] = i
+		if w == 0 {
+			keys[i] = math.Inf(-1)
+			continue
+		}
+		u := p.Float64Full()
+		for u == 0 {
+			u = p.Float64Full()
+		}
+		keys[i] = math.Log(u) / w
+	}
+
+	sort.SliceStable(idx, func(a, b int) bool {
+		return keys[idx[a]] > keys[idx[b]]
+	})
+	return idx[:k:k]
+}
+
+// PivotIndex returns a uniform pivot index in the closed interval [lo, hi], as randomized
+// quicksort and quickselect need for the subarray a[lo..hi]. Note the inclusive upper bound,
+// unlike IntRange. The span hi-lo+1 is computed in uint64, so every pair of bounds works,
+// including hi == math.MaxInt.
+// It panics if lo > hi.
+func (p *PCG64) PivotIndex(lo, hi int) int {
+	if lo > hi {
+		panic("invalid argument to PivotIndex")
+	}
+	span := uint64(hi) - uint64(lo) + 1
+	if span == 0 { // lo == math.MinInt and hi == math.MaxInt on a 64-bit platform
+		return int(p.Uint64())
+	}
+	return lo + int(p.Uint64n(span))
+}
+
+// Dice returns the result of rolling a die with the given number of sides, in the range [1, sides].
+// Note the 1-based result, unlike Uint64n.
+// It panics if sides < 1.
+func (p *PCG64) Dice(sides int) int {
+	if sides < 1 {
+		panic("invalid argument to Dice")
+	}
+	return 1 + int(p.Uint64n(uint64(sides)))
+}
+
+// DiceN returns the sum of rolling count dice with the given number of sides.
+// It panics if count < 0 or sides < 1.
+func (p *PCG64) DiceN(count, sides int) int {
+	if count < 0 || sides < 1 {
+		panic("invalid argument to DiceN")
+	}
+	sum := 0
+	for i := 0; i < count; i++ {
+		sum += p.Dice(sides)
+	}
+	return sum
+}
+
+// SortedFloat64s returns n uniform values in [0, 1) in ascending order, without sorting.
+// It draws n+1 positive exponential spacings -ln(U), U in (0, 1), and returns their running
+// sums divided by the total, which is distributed exactly as n sorted uniforms. The output is
+// strictly increasing unless two values fall closer than float64 resolution apart.
+// It panics if n < 0.
+func (p *PCG64) SortedFloat64s(n int) []float64 {
+	if n < 0 {
+		panic("invalid argument to SortedFloat64s")
+	}
+
+	res := make([]float64, n)
+	sum := 0.0
+	for i := range res {
+		sum += -math.Log(p.Float64OpenOpen())
+		res[i] = sum
+	}
+	sum += -math.Log(p.Float64OpenOpen())
+
+	const below1 = 1 - 1.0/(1<<53)
+	for i := range res {
+		res[i] /= sum
+		if res[i] >= 1 { // rounding can reach 1 when the last spacing is tiny
+			res[i] = below1
+		}
+	}
+	return res
+}
+
+// StratifiedFloat64 returns n jittered samples in [0, 1), one per stratum: [0, 1) is split into
+// n equal strata and res[i] = (i + Float64Full()) / n lies in the i-th. Compared with n independent
+// uniforms this avoids clustering and greatly reduces the variance of Monte Carlo integrals.
+// The samples are returned in stratum order; pass them through Shuffle when a random order is needed.
+// It panics if n < 0.
+func (p *PCG64) StratifiedFloat64(n int) []float64 {
+	if n < 0 {
+		panic("invalid argument to StratifiedFloat64")
+	}
+
+	res := make([]float64, n)
+	const below1 = 1 - 1.0/(1<<53)
+	for i := range res {
+		res[i] = (float64(i) + p.Float64Full()) / float64(n)
+		if res[i] >= 1 { // i + U can round up to n in the last stratum
+			res[i] = below1
+		}
+	}
+	return res
+}
+
+// SortedSampleK returns k distinct values from [0, n) in ascending order, every k-subset being
+// equally likely. It uses Vitter's Method D ("An Efficient Algorithm for Sequential Random
+// Sampling", ACM TOMS 1987), which draws the gap to each next selected value directly, so it
+// takes O(k) expected time and memory however large n is, and never materializes [0, n).
+// Once few enough values remain relative to k it finishes with Vitter's simpler Method A.
+// It panics if n < 0, k < 0 or k > n.
+func (p *PCG64) SortedSampleK(n, k int) []int {
+	if n < 0 || k < 0 || k > n {
+		panic("invalid argument to SortedSampleK")
+	}
+
+	res := make([]int, 0, k)
+	if k == 0 {
+		return res
+	}
+
+	// Method D switches to Method A once n <= alphaInv*k.
+	const alphaInv = 13
+
+	next := 0 // smallest value not yet skipped or selected
+	ninv := 1 / float64(k)
+	vprime := math.Exp(math.Log(p.Float64OpenOpen()) * ninv)
+	qu1 := n - k + 1
+	threshold := alphaInv * k
+	for k > 1 && threshold < n {
+		nreal, kreal := float64(n), float64(k)
+		qu1real := float64(qu1)
+		kmin1inv := 1 / (kreal - 1)
+
+		var s int
+		for {
+			// D2: generate the candidate skip s from the continuous envelope
+			var x float64
+			for {
+				x = nreal * (1 - vprime)
+				s = int(x)
+				if s < qu1 {
+					break
+				}
+				vprime = math.Exp(math.Log(p.Float64OpenOpen()) * ninv)
+			}
+			u := p.Float64OpenOpen()
+			negS := -float64(s)
+
+			// D3: the quick acceptance test
+			y1 := math.Exp(math.Log(u*nreal/qu1real) * kmin1inv)
+			vprime = y1 * (1 - x/nreal) * (qu1real / (negS + qu1real))
+			if vprime <= 1 {
+				break
+			}
+
+			// D4: the exact acceptance test
+			y2, top := 1.0, nreal-1
+			var bottom float64
+			var limit int
+			if k-1 > s {
+				bottom, limit = nreal-kreal, n-s
+			} else {
+				bottom, limit = nreal+negS-1, qu1
+			}
+			for t := n - 1; t >= limit; t-- {
+				y2 = y2 * top / bottom
+				top--
+				bottom--
+			}
+			if nreal/(nreal-x) >= y1*math.Exp(math.Log(y2)*kmin1inv) {
+				vprime = math.Exp(math.Log(p.Float64OpenOpen()) * kmin1inv)
+				break
+			}
+			vprime = math.Exp(math.Log(p.Float64OpenOpen()) * ninv)
+		}
+
+		// skip s values and select the next one
+		next += s
+		res = append(res, next)
+		next++
+		n -= s + 1
+		k--
+		ninv = kmin1inv
+		qu1 -= s
+		threshold -= alphaInv
+	}
+
+	if k > 1 {
+		return p.sortedSampleA(res, next, n, k)
+	}
+	s := min(int(float64(n)*vprime), n-1)
+	return append(res, next+s)
+}
+
+// sortedSampleA appends k sorted distinct values from [next, next+n) to res using
+// Vitter's Method A, which walks the skip distribution one value at a time.
+func (p *PCG64) sortedSampleA(res []int, next, n, k int) []int {
+	top := float64(n - k)
+	nreal := float64(n)
+	for ; k >= 2; k-- {
+		v := p.Float64OpenOpen()
+		s := 0
+		quot := top / nreal
+		for quot > v {
+			s++
+			top--
+			nreal--
+			quot = quot * top / nreal
+		}
+		next += s
+		res = append(res, next)
+		next++
+		nreal--
+	}
+	s := min(int(nreal*p.Float64OpenOpen()), int(nreal)-1)
+	return append(res, next+s)
+}
+
+// Bootstrap returns k samples drawn uniformly with replacement from data.
+// It is the core resampling step for bootstrap confidence intervals.
+// It panics if data is empty or k < 0.
+func (p *PCG64) Bootstrap(data []float64, k int) []float64 {
+	if len(data) == 0 || k < 0 {
+		panic("invalid argument to Bootstrap")
+	}
+	res := make([]float64, k)
+	for i := range res {
+		res[i] = data[p.Uint64n(uint64(len(data)))]
+	}
+	return res
+}
+
+// SampleOne returns a uniformly random element of s together with its index,
+// so callers that go on to modify the slice do not have to search for the element.
+// It panics if s is empty.
+func SampleOne[T any](p *PCG64, s []T) (T, int) {
+	if len(s) == 0 {
+		panic("invalid argument to SampleOne")
+	}
+	i := int(p.Uint64n(uint64(len(s))))
+	return s[i], i
+}
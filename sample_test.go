@@ -0,0 +1,641 @@
+package pcg
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestPCG64_PartialShuffle(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	const (
+		n      = 20
+		k      = 5
+		trials = 100000
+	)
+
+	counts := make([]int, n)
+	for trial := 0; trial < trials; trial++ {
+		arr := make([]int, n)
+		for i := range arr {
+			arr[i] = i
+		}
+		pcg.PartialShuffle(n, k, func(i, j int) {
+			arr[i], arr[j] = arr[j], arr[i]
+		})
+
+		if !isPermutation(arr, identity(n)) {
+			t.Fatalf("PartialShuffle() = %v; want a permutation of [0, %d)", arr, n)
+		}
+		seen := make(map[int]bool)
+		for _, v := range arr[:k] {
+			if seen[v] {
+				t.Fatalf("PartialShuffle() first %d = %v; element %d appears twice", k, arr[:k], v)
+			}
+			seen[v] = true
+			counts[v]++
+		}
+	}
+
+	want := float64(trials) * k / n
+	for v, c := range counts {
+		if math.Abs(float64(c)-want)/want > 0.05 {
+			t.Errorf("element %d appeared in the sample %d times; want ~%.0f", v, c, want)
+		}
+	}
+}
+
+func TestPCG64_PartialShuffle_InvalidArgs(t *testing.T) {
+	tests := []struct {
+		n, k int
+	}{
+		{-1, 0},
+		{5, -1},
+		{5, 6},
+	}
+
+	for _, tc := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("PartialShuffle(%d, %d) did not panic", tc.n, tc.k)
+				}
+			}()
+			NewPCG64(1, 2).PartialShuffle(tc.n, tc.k, func(i, j int) {})
+		}()
+	}
+}
+
+func TestPCG64_ShuffleDeterministic(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	arr := identity(10)
+	pcg.ShuffleDeterministic(len(arr), func(i, j int) {
+		arr[i], arr[j] = arr[j], arr[i]
+	})
+
+	// pinned: this permutation must be the same on every platform
+	want := []int{4, 9, 2, 5, 1, 6, 8, 3, 7, 0}
+	if !isArrayEqual(arr, want) {
+		t.Errorf("ShuffleDeterministic(10) = %v; want %v", arr, want)
+	}
+
+	shuffled := identity(10)
+	NewPCG64(42, 54).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	if !isArrayEqual(shuffled, arr) {
+		t.Errorf("Shuffle(10) = %v; want %v from ShuffleDeterministic", shuffled, arr)
+	}
+
+	for _, n := range []int{0, 1} {
+		NewPCG64(42, 54).ShuffleDeterministic(n, func(i, j int) {
+			t.Errorf("ShuffleDeterministic(%d) called swap(%d, %d)", n, i, j)
+		})
+	}
+}
+
+func TestPCG64_KPermutation(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, tc := range []struct{ n, k int }{{0, 0}, {1, 1}, {10, 0}, {10, 3}, {10, 10}, {1000, 50}} {
+		res := pcg.KPermutation(tc.n, tc.k)
+		if len(res) != tc.k {
+			t.Fatalf("KPermutation(%d, %d) len = %d; want %d", tc.n, tc.k, len(res), tc.k)
+		}
+		seen := make(map[int]bool, tc.k)
+		for _, v := range res {
+			if v < 0 || v >= tc.n || seen[v] {
+				t.Fatalf("KPermutation(%d, %d) = %v; want %d distinct values in [0, %d)", tc.n, tc.k, res, tc.k, tc.n)
+			}
+			seen[v] = true
+		}
+	}
+
+	// the sparse shuffle makes the same swaps as a dense partial Fisher-Yates
+	for _, tc := range []struct{ n, k int }{{10, 3}, {10, 9}, {1000, 50}} {
+		got := NewPCG64(7, 8).KPermutation(tc.n, tc.k)
+		dense := identity(tc.n)
+		NewPCG64(7, 8).PartialShuffle(tc.n, tc.k, func(i, j int) { dense[i], dense[j] = dense[j], dense[i] })
+		if !reflect.DeepEqual(got, dense[:tc.k]) {
+			t.Errorf("KPermutation(%d, %d) = %v; want %v from PartialShuffle", tc.n, tc.k, got, dense[:tc.k])
+		}
+	}
+
+	// a tiny sample of a huge range needs no memory proportional to n
+	if res := NewPCG64(1, 2).KPermutation(1<<30, 3); len(res) != 3 || cap(res) != 3 {
+		t.Errorf("KPermutation(1<<30, 3) len = %d, cap = %d; want 3, 3", len(res), cap(res))
+	}
+
+	// all 4*3 = 12 ordered pairs from [0, 4) should be equally likely
+	const rounds = 120000
+	counts := make(map[[2]int]int)
+	for i := 0; i < rounds; i++ {
+		res := pcg.KPermutation(4, 2)
+		counts[[2]int{res[0], res[1]}]++
+	}
+	if len(counts) != 12 {
+		t.Fatalf("KPermutation(4, 2) produced %d distinct tuples; want 12", len(counts))
+	}
+	want := float64(rounds) / 12
+	for tuple, c := range counts {
+		if math.Abs(float64(c)-want)/want > 0.05 {
+			t.Errorf("KPermutation(4, 2) tuple %v drawn %d times; want ~%.0f", tuple, c, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("KPermutation(3, 4) did not panic")
+		}
+	}()
+	pcg.KPermutation(3, 4)
+}
+
+func TestPCG64_PermutationPair(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	ref := NewPCG64(42, 54)
+
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		perm, inverse := pcg.PermutationPair(n)
+		if len(perm) != n || len(inverse) != n {
+			t.Fatalf("PermutationPair(%d) lens = %d, %d; want %d", n, len(perm), len(inverse), n)
+		}
+		for i := range perm {
+			if inverse[perm[i]] != i {
+				t.Fatalf("PermutationPair(%d): inverse[perm[%d]] = %d; want %d", n, i, inverse[perm[i]], i)
+			}
+		}
+		if want := ref.Perm(n); !reflect.DeepEqual(perm, want) {
+			t.Errorf("PermutationPair(%d) perm = %v; want Perm(%d) = %v", n, perm, n, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("PermutationPair(-1) did not panic")
+		}
+	}()
+	pcg.PermutationPair(-1)
+}
+
+func TestPCG64_ShuffleAny(t *testing.T) {
+	words := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	got := append([]string(nil), words...)
+	NewPCG64(42, 54).ShuffleAny(got)
+
+	want := append([]string(nil), words...)
+	NewPCG64(42, 54).Shuffle(len(want), func(i, j int) { want[i], want[j] = want[j], want[i] })
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ShuffleAny([]string) = %v; want %v as Shuffle", got, want)
+	}
+
+	type point struct{ x, y int }
+	points := make([]point, 50)
+	for i := range points {
+		points[i] = point{i, -i}
+	}
+	NewPCG64(12345, 67890).ShuffleAny(points)
+	seen := make(map[point]bool)
+	for _, pt := range points {
+		if pt.x < 0 || pt.x >= len(points) || pt.y != -pt.x || seen[pt] {
+			t.Fatalf("ShuffleAny([]point) = %v; want a permutation of the input", points)
+		}
+		seen[pt] = true
+	}
+
+	defer func() {
+		r := recover()
+		if msg, _ := r.(string); !strings.Contains(msg, "non-slice") {
+			t.Errorf("ShuffleAny(map) panic = %v; want a non-slice panic", r)
+		}
+	}()
+	NewPCG64(1, 2).ShuffleAny(map[int]int{1: 1})
+}
+
+func TestPCG64_WeightedIndex(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	weights := []float64{1, 0, 3, 6, 0.5}
+
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+
+	const draws = 200000
+	counts := make([]int, len(weights))
+	for i := 0; i < draws; i++ {
+		counts[pcg.WeightedIndex(weights)]++
+	}
+	for i, w := range weights {
+		want := w / total
+		if got := float64(counts[i]) / draws; math.Abs(got-want) > 0.05*want+0.002 {
+			t.Errorf("WeightedIndex frequency of %d = %f; want ~%f", i, got, want)
+		}
+	}
+	if counts[1] != 0 {
+		t.Errorf("WeightedIndex returned zero-weight index 1 %d times; want 0", counts[1])
+	}
+
+	if got := pcg.WeightedIndex([]float64{0, 0, 2}); got != 2 {
+		t.Errorf("WeightedIndex([0 0 2]) = %d; want 2", got)
+	}
+}
+
+func TestPCG64_WeightedIndex_InvalidArgs(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	for _, weights := range [][]float64{nil, {0, 0}, {1, -1}, {1, math.NaN()}, {1, math.Inf(1)}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("WeightedIndex(%v) did not panic", weights)
+				}
+			}()
+			pcg.WeightedIndex(weights)
+		}()
+	}
+}
+
+func TestPCG64_WeightedSample(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	weights := []float64{1, 2, 3, 4, 0}
+	total := 10.0
+
+	const trials = 100000
+	firstCounts := make([]int, len(weights))
+	for trial := 0; trial < trials; trial++ {
+		res := pcg.WeightedSample(weights, 3)
+		if len(res) != 3 {
+			t.Fatalf("WeightedSample() len = %d; want 3", len(res))
+		}
+		seen := make(map[int]bool)
+		for _, i := range res {
+			if seen[i] {
+				t.Fatalf("WeightedSample() = %v; index %d appears twice", res, i)
+			}
+			if weights[i] == 0 {
+				t.Fatalf("WeightedSample() = %v; zero-weight index %d was drawn", res, i)
+			}
+			seen[i] = true
+		}
+		firstCounts[res[0]]++
+	}
+
+	for i, w := range weights {
+		want := float64(trials) * w / total
+		if math.Abs(float64(firstCounts[i])-want) > 0.05*want+10 {
+			t.Errorf("index %d drawn first %d times; want ~%.0f", i, firstCounts[i], want)
+		}
+	}
+}
+
+func TestPCG64_WeightedSample_InvalidArgs(t *testing.T) {
+	tests := []struct {
+		weights []float64
+		k       int
+	}{
+		{[]float64{1, -1}, 1},
+		{[]float64{1, 2}, 3},
+		{[]float64{1, 2}, -1},
+	}
+
+	for _, tc := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("WeightedSample(%v, %d) did not panic", tc.weights, tc.k)
+				}
+			}()
+			NewPCG64(1, 2).WeightedSample(tc.weights, tc.k)
+		}()
+	}
+}
+
+func TestPCG64_PivotIndex(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, tc := range []struct{ lo, hi int }{{0, 0}, {3, 4}, {10, 19}, {-3, 3}} {
+		seen := make(map[int]bool)
+		for i := 0; i < 1000; i++ {
+			v := pcg.PivotIndex(tc.lo, tc.hi)
+			if v < tc.lo || v > tc.hi {
+				t.Fatalf("PivotIndex(%d, %d) = %d; want a value in [lo, hi]", tc.lo, tc.hi, v)
+			}
+			seen[v] = true
+		}
+		// both endpoints are reachable
+		if !seen[tc.lo] || !seen[tc.hi] {
+			t.Errorf("PivotIndex(%d, %d) never returned an endpoint in 1000 draws", tc.lo, tc.hi)
+		}
+	}
+
+	// the upper end of the int range is a valid inclusive bound
+	for _, tc := range []struct{ lo, hi int }{{math.MaxInt - 1, math.MaxInt}, {math.MinInt, math.MaxInt}} {
+		seenHi := false
+		for i := 0; i < 1000; i++ {
+			v := pcg.PivotIndex(tc.lo, tc.hi)
+			if v < tc.lo {
+				t.Fatalf("PivotIndex(%d, %d) = %d; want a value in [lo, hi]", tc.lo, tc.hi, v)
+			}
+			seenHi = seenHi || v == tc.hi
+		}
+		if tc.lo == math.MaxInt-1 && !seenHi {
+			t.Errorf("PivotIndex(%d, %d) never returned hi in 1000 draws", tc.lo, tc.hi)
+		}
+	}
+	if got := pcg.PivotIndex(math.MaxInt, math.MaxInt); got != math.MaxInt {
+		t.Errorf("PivotIndex(MaxInt, MaxInt) = %d; want %d", got, math.MaxInt)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("PivotIndex(5, 4) did not panic")
+		}
+	}()
+	pcg.PivotIndex(5, 4)
+}
+
+func TestPCG64_Dice(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	for _, sides := range []int{1, 2, 6, 20} {
+		for i := 0; i < 1000; i++ {
+			if v := pcg.Dice(sides); v < 1 || v > sides {
+				t.Fatalf("Dice(%d) = %d; want a value in [1, %d]", sides, v, sides)
+			}
+		}
+	}
+
+	if v := pcg.DiceN(0, 6); v != 0 {
+		t.Errorf("DiceN(0, 6) = %d; want 0", v)
+	}
+}
+
+func TestPCG64_DiceN_2d6(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	counts := make([]int, 13)
+	for i := 0; i < 100000; i++ {
+		v := pcg.DiceN(2, 6)
+		if v < 2 || v > 12 {
+			t.Fatalf("DiceN(2, 6) = %d; want a value in [2, 12]", v)
+		}
+		counts[v]++
+	}
+
+	for v := 2; v <= 12; v++ {
+		if v != 7 && counts[v] >= counts[7] {
+			t.Errorf("2d6 count of %d = %d; want fewer than the count of 7 (%d)", v, counts[v], counts[7])
+		}
+	}
+}
+
+func TestPCG64_Dice_InvalidArgs(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"Dice(0)", func() { pcg.Dice(0) }},
+		{"DiceN(-1, 6)", func() { pcg.DiceN(-1, 6) }},
+		{"DiceN(2, 0)", func() { pcg.DiceN(2, 0) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestPCG64_SortedFloat64s(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, n := range []int{0, 1, 2, 100} {
+		res := pcg.SortedFloat64s(n)
+		if len(res) != n {
+			t.Fatalf("SortedFloat64s(%d) len = %d; want %d", n, len(res), n)
+		}
+		if !sort.Float64sAreSorted(res) {
+			t.Errorf("SortedFloat64s(%d) = %v; want ascending order", n, res)
+		}
+	}
+
+	const n = 100000
+	res := pcg.SortedFloat64s(n)
+	for i, v := range res {
+		if v < 0 || v >= 1 {
+			t.Fatalf("SortedFloat64s(%d)[%d] = %f; want a value in [0, 1)", n, i, v)
+		}
+		if i > 0 && v <= res[i-1] {
+			t.Fatalf("SortedFloat64s(%d)[%d] = %v <= previous %v; want strictly increasing", n, i, v, res[i-1])
+		}
+	}
+
+	// the k-th of n sorted uniforms has mean k/(n+1)
+	for _, k := range []int{n / 10, n / 2, 9 * n / 10} {
+		if got, want := res[k-1], float64(k)/(n+1); math.Abs(got-want) > 0.05 {
+			t.Errorf("SortedFloat64s(%d)[%d] = %f; want ~%f", n, k-1, got, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SortedFloat64s(-1) did not panic")
+		}
+	}()
+	pcg.SortedFloat64s(-1)
+}
+
+func TestPCG64_SortedSampleK(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	// the small cases run Method A only, the large ones Method D first
+	for _, tc := range []struct{ n, k int }{
+		{0, 0}, {5, 0}, {1, 1}, {7, 7}, {20, 5}, {40, 3}, {1000, 10}, {1000000, 100}, {1 << 30, 64},
+	} {
+		res := pcg.SortedSampleK(tc.n, tc.k)
+		if len(res) != tc.k {
+			t.Fatalf("SortedSampleK(%d, %d) len = %d; want %d", tc.n, tc.k, len(res), tc.k)
+		}
+		for i, v := range res {
+			if v < 0 || v >= tc.n {
+				t.Fatalf("SortedSampleK(%d, %d)[%d] = %d; want a value in [0, %d)", tc.n, tc.k, i, v, tc.n)
+			}
+			if i > 0 && v <= res[i-1] {
+				t.Fatalf("SortedSampleK(%d, %d) = %v; want strictly increasing values", tc.n, tc.k, res)
+			}
+		}
+	}
+
+	if got := pcg.SortedSampleK(7, 7); !reflect.DeepEqual(got, identity(7)) {
+		t.Errorf("SortedSampleK(7, 7) = %v; want %v", got, identity(7))
+	}
+
+	// every value is selected with probability k/n, both through Method A (n <= 13k)
+	// and through Method D (n > 13k)
+	for _, tc := range []struct{ n, k int }{{20, 5}, {100, 2}, {200, 10}} {
+		const runs = 40000
+		counts := make([]int, tc.n)
+		for r := 0; r < runs; r++ {
+			for _, v := range pcg.SortedSampleK(tc.n, tc.k) {
+				counts[v]++
+			}
+		}
+		want := float64(runs*tc.k) / float64(tc.n)
+		for v, c := range counts {
+			if math.Abs(float64(c)-want) > 0.1*want {
+				t.Errorf("SortedSampleK(%d, %d) picked %d in %d of %d runs; want ~%.0f", tc.n, tc.k, v, c, runs, want)
+			}
+		}
+	}
+
+	for _, tc := range []struct{ n, k int }{{-1, 0}, {3, -1}, {3, 4}} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("SortedSampleK(%d, %d) did not panic", tc.n, tc.k)
+				}
+			}()
+			pcg.SortedSampleK(tc.n, tc.k)
+		}()
+	}
+}
+
+func TestPCG64_StratifiedFloat64(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, n := range []int{0, 1, 7, 1000} {
+		res := pcg.StratifiedFloat64(n)
+		if len(res) != n {
+			t.Fatalf("StratifiedFloat64(%d) len = %d; want %d", n, len(res), n)
+		}
+		for i, v := range res {
+			if v < 0 || v >= 1 {
+				t.Fatalf("StratifiedFloat64(%d)[%d] = %v; want a value in [0, 1)", n, i, v)
+			}
+			if got := int(v * float64(n)); got != i {
+				t.Errorf("StratifiedFloat64(%d)[%d] = %v falls in stratum %d; want %d", n, i, v, got, i)
+			}
+		}
+	}
+
+	// the stratified estimate of the integral of x² over [0, 1) beats plain sampling by far
+	const n, trials = 100, 200
+	var stratErr, plainErr float64
+	for trial := 0; trial < trials; trial++ {
+		var s, u float64
+		for _, x := range pcg.StratifiedFloat64(n) {
+			s += x * x
+		}
+		for i := 0; i < n; i++ {
+			x := pcg.Float64Full()
+			u += x * x
+		}
+		stratErr += math.Abs(s/n - 1.0/3)
+		plainErr += math.Abs(u/n - 1.0/3)
+	}
+	if stratErr*10 > plainErr {
+		t.Errorf("stratified mean error %g; want well below plain sampling error %g", stratErr/trials, plainErr/trials)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("StratifiedFloat64(-1) did not panic")
+		}
+	}()
+	pcg.StratifiedFloat64(-1)
+}
+
+func TestPCG64_Bootstrap(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	data := []float64{1, 3, 4, 7, 10, 12, 20}
+	dataMean, _ := meanAndVariance(data)
+
+	const rounds = 2000
+	sum := 0.0
+	for i := 0; i < rounds; i++ {
+		res := pcg.Bootstrap(data, len(data))
+		if len(res) != len(data) {
+			t.Fatalf("Bootstrap() len = %d; want %d", len(res), len(data))
+		}
+		m, _ := meanAndVariance(res)
+		sum += m
+	}
+
+	if got := sum / rounds; math.Abs(got-dataMean)/dataMean > 0.03 {
+		t.Errorf("mean of bootstrap means = %f; want ~%f", got, dataMean)
+	}
+	if res := pcg.Bootstrap(data, 0); len(res) != 0 {
+		t.Errorf("Bootstrap(data, 0) = %v; want an empty slice", res)
+	}
+}
+
+func TestPCG64_Bootstrap_InvalidArgs(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"empty data", func() { pcg.Bootstrap(nil, 1) }},
+		{"negative k", func() { pcg.Bootstrap([]float64{1}, -1) }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Bootstrap with %s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestSampleOne(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	s := []string{"a", "b", "c", "d", "e"}
+
+	const draws = 100000
+	counts := make([]int, len(s))
+	for i := 0; i < draws; i++ {
+		v, idx := SampleOne(pcg, s)
+		if idx < 0 || idx >= len(s) {
+			t.Fatalf("SampleOne() index = %d; want a value in [0, %d)", idx, len(s))
+		}
+		if v != s[idx] {
+			t.Fatalf("SampleOne() = (%q, %d); want s[%d] = %q", v, idx, idx, s[idx])
+		}
+		counts[idx]++
+	}
+
+	want := float64(draws) / float64(len(s))
+	for i, c := range counts {
+		if math.Abs(float64(c)-want)/want > 0.05 {
+			t.Errorf("SampleOne() index %d drawn %d times; want ~%.0f", i, c, want)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SampleOne with empty slice did not panic")
+		}
+	}()
+	SampleOne(pcg, []int{})
+}
+
+func identity(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i
+	}
+	return res
+}
@@ -0,0 +1,139 @@
+package pcg
+
+import (
+	"math"
+	"math/bits"
+)
+
+// SelfTestUniformity draws samples Uint64 values from p, counts them into bins equal-width
+// bins spanning the full uint64 range, and returns the p-value of Pearson's chi-square test
+// against the uniform distribution. Values are counted as they are drawn, so memory use does
+// not grow with samples. A small p-value (say below 0.001) means the output is unlikely to be
+// uniform. Downstream tests can use it to assert the quality of a generator they configured.
+// It panics if samples <= 0 or bins < 2.
+func SelfTestUniformity(p *PCG64, samples, bins int) (pValue float64) {
+	if samples <= 0 || bins < 2 {
+		panic("invalid argument to SelfTestUniformity")
+	}
+
+	counts := make([]int, bins)
+	for i := 0; i < samples; i++ {
+		idx, _ := bits.Mul64(p.Uint64(), uint64(bins))
+		counts[idx]++
+	}
+
+	expected := float64(samples) / float64(bins)
+	chi := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	return chiSquareSurvival(chi, float64(bins-1))
+}
+
+// chiSquareSurvival returns P(X >= x) for X chi-square distributed with k degrees of freedom.
+func chiSquareSurvival(x, k float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return gammaQ(k/2, x/2)
+}
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a, x), using the power
+// series of P = 1 - Q below x = a+1 and Lentz's continued fraction for Q above it.
+func gammaQ(a, x float64) float64 {
+	const (
+		eps     = 1e-15
+		maxIter = 1000
+		tiny    = 1e-300
+	)
+	lga, _ := math.Lgamma(a)
+	prefix := math.Exp(a*math.Log(x) - x - lga)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < maxIter; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*eps {
+				break
+			}
+		}
+		return 1 - sum*prefix
+	}
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < maxIter; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < eps {
+			break
+		}
+	}
+	return h * prefix
+}
+
+// MinMax draws samples Uint64 values from p and returns the smallest and largest observed.
+// It is a quick diagnostic for checking that the output covers the full 64-bit range:
+// after n draws both extremes are expected within about 2^64/n of the ends of the range.
+// It panics if samples <= 0.
+func (p *PCG64) MinMax(samples int) (min, max uint64) {
+	if samples <= 0 {
+		panic("invalid argument to MinMax")
+	}
+	min = math.MaxUint64
+	for i := 0; i < samples; i++ {
+		v := p.Uint64()
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return min, max
+}
+
+// EntropyEstimate draws samples bytes from p, in the order Read produces them, and returns
+// their empirical Shannon entropy in bits per byte. A good generator scores very close to
+// the maximum of 8; the plug-in estimate is biased low by about 184/samples bits, so use at
+// least a few hundred thousand bytes before comparing against a threshold such as 7.99.
+// It panics if samples <= 0.
+func (p *PCG64) EntropyEstimate(samples int) float64 {
+	if samples <= 0 {
+		panic("invalid argument to EntropyEstimate")
+	}
+
+	var counts [256]int
+	var word uint64
+	for i := 0; i < samples; i++ {
+		if i%8 == 0 {
+			word = p.Uint64()
+		}
+		counts[byte(word)]++
+		word >>= 8
+	}
+
+	h := 0.0
+	for _, c := range counts {
+		if c > 0 {
+			f := float64(c) / float64(samples)
+			h -= f * math.Log2(f)
+		}
+	}
+	return h
+}
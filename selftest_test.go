@@ -0,0 +1,84 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestChiSquareSurvival(t *testing.T) {
+	for _, k := range []float64{1, 3, 9, 15, 99} {
+		for _, x := range []float64{0.1, 1, 5, 10, 30, 150} {
+			want := distuv.ChiSquared{K: k}.Survival(x)
+			if got := chiSquareSurvival(x, k); math.Abs(got-want) > 1e-9 {
+				t.Errorf("chiSquareSurvival(%v, %v) = %v; want %v", x, k, got, want)
+			}
+		}
+	}
+}
+
+func TestSelfTestUniformity(t *testing.T) {
+	if p := SelfTestUniformity(NewPCG64(42, 54), 10000, 10); p < 0.001 {
+		t.Errorf("SelfTestUniformity(seeded generator) = %f; want a passing p-value", p)
+	}
+
+	// Zero state with an even zero increment leaves the LCG stuck at 0, so every draw is 0.
+	broken := NewPCG64FromState(0, 0, 0, 0)
+	if p := SelfTestUniformity(broken, 10000, 10); p >= 0.001 {
+		t.Errorf("SelfTestUniformity(broken generator) = %f; want a failing p-value", p)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("SelfTestUniformity with 1 bin did not panic")
+		}
+	}()
+	SelfTestUniformity(NewPCG64(1, 2), 100, 1)
+}
+
+func TestPCG64_MinMax(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	min, max := pcg.MinMax(10000)
+	if min > max {
+		t.Fatalf("MinMax(10000) = (%d, %d); want min <= max", min, max)
+	}
+	if lim := uint64(math.MaxUint64 / 100); min > lim {
+		t.Errorf("MinMax(10000) min = %d; want below 1%% of the range (%d)", min, lim)
+	}
+	if lim := uint64(math.MaxUint64 / 100 * 99); max < lim {
+		t.Errorf("MinMax(10000) max = %d; want above 99%% of the range (%d)", max, lim)
+	}
+
+	ref := NewPCG64(1, 2)
+	v := ref.Uint64()
+	if min, max := NewPCG64(1, 2).MinMax(1); min != v || max != v {
+		t.Errorf("MinMax(1) = (%d, %d); want (%d, %d)", min, max, v, v)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MinMax(0) did not panic")
+		}
+	}()
+	pcg.MinMax(0)
+}
+
+func TestPCG64_EntropyEstimate(t *testing.T) {
+	if h := NewPCG64(42, 54).EntropyEstimate(1 << 20); h < 7.99 || h > 8 {
+		t.Errorf("EntropyEstimate(1<<20) = %f; want in [7.99, 8]", h)
+	}
+
+	// a single byte carries no observed uncertainty
+	if h := NewPCG64(42, 54).EntropyEstimate(1); h != 0 {
+		t.Errorf("EntropyEstimate(1) = %f; want 0", h)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("EntropyEstimate(0) did not panic")
+		}
+	}()
+	NewPCG64(42, 54).EntropyEstimate(0)
+}
@@ -0,0 +1,67 @@
+package pcg
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// streamChunk is the buffer size used when streaming random bytes to a writer.
+// It is a multiple of 16, so chunked output matches a single Read of the same length.
+const streamChunk = 4096
+
+// StreamN writes n random bytes to w in fixed-size chunks, without allocating a buffer of size n.
+// The bytes are the same as those of a single Read into an n-byte buffer.
+// It returns the number of bytes written and the first write error encountered;
+// a writer that accepts fewer bytes than offered without an error yields io.ErrShortWrite.
+func (p *PCG64) StreamN(w io.Writer, n int64) (int64, error) {
+	if n <= 0 {
+		return 0, nil
+	}
+	return p.stream(w, n, make([]byte, min(n, streamChunk)))
+}
+
+// streamBufs holds chunk buffers reused across CopyN calls.
+var streamBufs = sync.Pool{
+	New: func() any { return new([streamChunk]byte) },
+}
+
+// CopyN writes exactly n random bytes to dst, the same bytes StreamN would write, using a
+// chunk buffer reused across calls so that repeated copies do not allocate. It returns the
+// number of bytes written. A write error, or a write that accepts fewer bytes than offered, is
+// wrapped as "pcg: short write after X bytes" so that errors.Is still matches the cause
+// (io.ErrShortWrite for the silent case).
+func (p *PCG64) CopyN(dst io.Writer, n int64) (int64, error) {
+	if n <= 0 {
+		return 0, nil
+	}
+
+	buf := streamBufs.Get().(*[streamChunk]byte)
+	defer streamBufs.Put(buf)
+
+	written, err := p.stream(dst, n, buf[:])
+	if err != nil {
+		return written, fmt.Errorf("pcg: short write after %d bytes: %w", written, err)
+	}
+	return written, nil
+}
+
+// stream writes n random bytes to w through buf, which must hold a multiple of 16 bytes
+// unless it is at least n long, so that the output matches a single Read.
+func (p *PCG64) stream(w io.Writer, n int64, buf []byte) (int64, error) {
+	var written int64
+	for written < n {
+		chunk := buf[:min(n-written, int64(len(buf)))]
+		p.Read(chunk)
+
+		m, err := w.Write(chunk)
+		written += int64(m)
+		if err != nil {
+			return written, err
+		}
+		if m != len(chunk) {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
+}
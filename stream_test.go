@@ -0,0 +1,100 @@
+package pcg
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestPCG64_StreamN(t *testing.T) {
+	for _, n := range []int64{0, 1, 15, 4096, 4097, 10000} {
+		var out bytes.Buffer
+		written, err := NewPCG64(42, 54).StreamN(&out, n)
+		if err != nil {
+			t.Fatalf("StreamN(%d) error = %v; want nil", n, err)
+		}
+		if written != n || int64(out.Len()) != n {
+			t.Errorf("StreamN(%d) wrote %d bytes (buffer holds %d); want %d", n, written, out.Len(), n)
+		}
+
+		want := make([]byte, n)
+		NewPCG64(42, 54).Read(want)
+		if !bytes.Equal(out.Bytes(), want) {
+			t.Errorf("StreamN(%d) bytes differ from Read of the same length", n)
+		}
+	}
+}
+
+// failingWriter accepts up to limit bytes and then fails.
+type failingWriter struct {
+	limit int
+	err   error
+}
+
+func (w *failingWriter) Write(b []byte) (int, error) {
+	if len(b) <= w.limit {
+		w.limit -= len(b)
+		return len(b), nil
+	}
+	n := w.limit
+	w.limit = 0
+	return n, w.err
+}
+
+func TestPCG64_StreamN_WriteError(t *testing.T) {
+	errDisk := errors.New("disk full")
+	w := &failingWriter{limit: 5000, err: errDisk}
+
+	written, err := NewPCG64(1, 2).StreamN(w, 10000)
+	if !errors.Is(err, errDisk) {
+		t.Errorf("StreamN() error = %v; want %v", err, errDisk)
+	}
+	if written != 5000 {
+		t.Errorf("StreamN() written = %d; want 5000", written)
+	}
+
+	written, err = NewPCG64(1, 2).StreamN(&failingWriter{limit: 10}, 100)
+	if err != io.ErrShortWrite || written != 10 {
+		t.Errorf("StreamN() = (%d, %v); want (10, %v)", written, err, io.ErrShortWrite)
+	}
+}
+
+func TestPCG64_CopyN(t *testing.T) {
+	for _, n := range []int64{0, 1, 4097, 10000} {
+		var got, want bytes.Buffer
+		written, err := NewPCG64(42, 54).CopyN(&got, n)
+		if err != nil || written != n {
+			t.Fatalf("CopyN(%d) = (%d, %v); want (%d, nil)", n, written, err, n)
+		}
+		NewPCG64(42, 54).StreamN(&want, n)
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Errorf("CopyN(%d) bytes differ from StreamN", n)
+		}
+	}
+}
+
+func TestPCG64_CopyN_WriteError(t *testing.T) {
+	errDisk := errors.New("disk full")
+	written, err := NewPCG64(1, 2).CopyN(&failingWriter{limit: 5000, err: errDisk}, 10000)
+	if written != 5000 || !errors.Is(err, errDisk) {
+		t.Errorf("CopyN() = (%d, %v); want (5000, an error wrapping %v)", written, err, errDisk)
+	}
+	if want := "pcg: short write after 5000 bytes: disk full"; err == nil || err.Error() != want {
+		t.Errorf("CopyN() error = %v; want %q", err, want)
+	}
+
+	written, err = NewPCG64(1, 2).CopyN(&failingWriter{limit: 10}, 100)
+	if written != 10 || !errors.Is(err, io.ErrShortWrite) {
+		t.Errorf("CopyN() = (%d, %v); want (10, an error wrapping %v)", written, err, io.ErrShortWrite)
+	}
+}
+
+func BenchmarkPCG64_CopyN(b *testing.B) {
+	pcg := NewPCG64(42, 54)
+	b.ReportAllocs()
+	b.SetBytes(1 << 16)
+	for i := 0; i < b.N; i++ {
+		pcg.CopyN(io.Discard, 1<<16)
+	}
+}
@@ -19,8 +19,8 @@ func TestPCG_MarshalBinary_Stress(t *testing.T) {
 		if err != nil {
 			t.Fatalf("MarshalBinary failed: %v", err)
 		}
-		if len(b) != 20 {
-			t.Errorf("MarshalBinary returned a slice of length %d; expected 20", len(b))
+		if len(b) != 21 {
+			t.Errorf("MarshalBinary returned a slice of length %d; expected 21", len(b))
 		}
 
 		if b[0] != 'p' || b[1] != 'c' || b[2] != 'g' || b[3] != ':' {
@@ -0,0 +1,88 @@
+package pcg
+
+import (
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+)
+
+// Default charsets for RandString.
+const (
+	CharsetDigits       = "0123456789"
+	CharsetLetters      = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	CharsetAlphanumeric = CharsetLetters + CharsetDigits
+)
+
+// RandString returns a string of n characters, each picked uniformly from charset.
+// charset may contain multi-byte characters; it is indexed by rune, not by byte.
+// It panics if n < 0 or charset is empty.
+func (p *PCG64) RandString(n int, charset string) string {
+	if n < 0 || charset == "" {
+		panic("invalid argument to RandString")
+	}
+
+	runes := []rune(charset)
+	var sb strings.Builder
+	sb.Grow(n)
+	for i := 0; i < n; i++ {
+		sb.WriteRune(runes[p.Uint64n(uint64(len(runes)))])
+	}
+	return sb.String()
+}
+
+// surrogateMin and surrogateCount describe the UTF-16 surrogate block U+D800..U+DFFF,
+// which holds no valid Unicode scalar values.
+const (
+	surrogateMin   = 0xD800
+	surrogateCount = 0x800
+)
+
+// RandUTF8 returns a valid UTF-8 string of n code points, each drawn uniformly from all
+// Unicode scalar values: U+0000 to U+10FFFF excluding the surrogates U+D800 to U+DFFF.
+// Most of that range lies in the supplementary planes, so most characters encode to four
+// bytes and many are unassigned; the point is to exercise string handling, not to look like text.
+// It panics if n < 0.
+func (p *PCG64) RandUTF8(n int) string {
+	if n < 0 {
+		panic("invalid argument to RandUTF8")
+	}
+
+	var sb strings.Builder
+	sb.Grow(4 * n)
+	for i := 0; i < n; i++ {
+		r := rune(p.Uint64n(utf8.MaxRune + 1 - surrogateCount))
+		if r >= surrogateMin {
+			r += surrogateCount
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// UUIDv4 returns a random (version 4) UUID as defined by RFC 4122.
+// The 16 bytes come from Block16, with the version nibble set to 4 and the variant bits set to 10.
+// These UUIDs are NOT cryptographically random: anyone who learns the generator state can predict them.
+func (p *PCG64) UUIDv4() [16]byte {
+	u := p.Block16()
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+	return u
+}
+
+// UUIDv4String returns a random UUID in the canonical hyphenated form, e.g.
+// "xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx". See UUIDv4.
+func (p *PCG64) UUIDv4String() string {
+	u := p.UUIDv4()
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
@@ -0,0 +1,129 @@
+package pcg
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPCG64_RandString(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		n       int
+		charset string
+	}{
+		{0, CharsetAlphanumeric},
+		{1, CharsetDigits},
+		{32, CharsetAlphanumeric},
+		{100, CharsetLetters},
+		{16, "αβγδ"},
+	}
+
+	for _, tc := range tests {
+		s := pcg.RandString(tc.n, tc.charset)
+		if got := utf8.RuneCountInString(s); got != tc.n {
+			t.Errorf("RandString(%d, %q) has %d characters; want %d", tc.n, tc.charset, got, tc.n)
+		}
+		for _, r := range s {
+			if !strings.ContainsRune(tc.charset, r) {
+				t.Errorf("RandString(%d, %q) = %q; %q is not in the charset", tc.n, tc.charset, s, r)
+			}
+		}
+	}
+
+	a := NewPCG64(1, 2).RandString(20, CharsetAlphanumeric)
+	b := NewPCG64(1, 2).RandString(20, CharsetAlphanumeric)
+	if a != b {
+		t.Errorf("RandString() = %q and %q; want identical strings for the same seed", a, b)
+	}
+}
+
+func TestPCG64_RandString_InvalidArgs(t *testing.T) {
+	pcg := NewPCG64(1, 2)
+
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"negative length", func() { pcg.RandString(-1, CharsetDigits) }},
+		{"empty charset", func() { pcg.RandString(5, "") }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("RandString with %s did not panic", tc.name)
+				}
+			}()
+			tc.fn()
+		})
+	}
+}
+
+func TestPCG64_RandUTF8(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	for _, n := range []int{0, 1, 17, 1000} {
+		s := pcg.RandUTF8(n)
+		if !utf8.ValidString(s) {
+			t.Errorf("RandUTF8(%d) = %q; want valid UTF-8", n, s)
+		}
+		if got := utf8.RuneCountInString(s); got != n {
+			t.Errorf("RandUTF8(%d) has %d runes; want %d", n, got, n)
+		}
+	}
+
+	// every encoded length shows up; one-byte runes are about 1 in 8700
+	var lengths [utf8.UTFMax + 1]int
+	for _, r := range pcg.RandUTF8(200000) {
+		if r >= 0xD800 && r <= 0xDFFF {
+			t.Fatalf("RandUTF8() produced the surrogate %U", r)
+		}
+		lengths[utf8.RuneLen(r)]++
+	}
+	for l := 1; l <= utf8.UTFMax; l++ {
+		if lengths[l] == 0 {
+			t.Errorf("RandUTF8(200000) produced no %d-byte runes", l)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("RandUTF8(-1) did not panic")
+		}
+	}()
+	pcg.RandUTF8(-1)
+}
+
+func TestPCG64_UUIDv4(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	for i := 0; i < 1000; i++ {
+		u := pcg.UUIDv4()
+		if v := u[6] >> 4; v != 0x4 {
+			t.Fatalf("UUIDv4() version nibble = %#x; want 0x4", v)
+		}
+		if v := u[8] >> 4; v < 0x8 || v > 0xb {
+			t.Fatalf("UUIDv4() variant nibble = %#x; want one of 0x8-0xb", v)
+		}
+	}
+}
+
+func TestPCG64_UUIDv4String(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		s := pcg.UUIDv4String()
+		if !pattern.MatchString(s) {
+			t.Fatalf("UUIDv4String() = %q; want the canonical hyphenated v4 format", s)
+		}
+		if seen[s] {
+			t.Fatalf("UUIDv4String() returned %q twice", s)
+		}
+		seen[s] = true
+	}
+}
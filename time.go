@@ -0,0 +1,16 @@
+package pcg
+
+import "time"
+
+// Time returns a uniformly random time in [start, end) with nanosecond resolution,
+// drawn with Int64Range over the nanoseconds between start and end. The result carries
+// the location and monotonic clock reading (if any) of start.
+// The window is limited to the range of a time.Duration, about 292 years.
+// It panics if end is not after start or the window is too long.
+func (p *PCG64) Time(start, end time.Time) time.Time {
+	span := end.Sub(start)
+	if span <= 0 || !start.Add(span).Equal(end) { // Sub saturates for windows that are too long
+		panic("invalid argument to Time")
+	}
+	return start.Add(time.Duration(p.Int64Range(0, int64(span))))
+}
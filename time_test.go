@@ -0,0 +1,48 @@
+package pcg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPCG64_Time(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	// every hour of the day should be hit
+	const n = 10000
+	hours := make([]int, 24)
+	for i := 0; i < n; i++ {
+		tm := pcg.Time(start, end)
+		if tm.Before(start) || !tm.Before(end) {
+			t.Fatalf("Time(%v, %v) = %v; want a time in [start, end)", start, end, tm)
+		}
+		hours[tm.Hour()]++
+	}
+	for h, c := range hours {
+		if c < n/24/2 {
+			t.Errorf("Time() hit hour %d only %d times in %d draws; want ~%d", h, c, n, n/24)
+		}
+	}
+
+	// a one-nanosecond window has a single possible result
+	if tm := pcg.Time(start, start.Add(1)); !tm.Equal(start) {
+		t.Errorf("Time(start, start+1ns) = %v; want %v", tm, start)
+	}
+
+	for _, tc := range []struct{ start, end time.Time }{
+		{start, start},
+		{end, start},
+		{start, start.AddDate(500, 0, 0)},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Time(%v, %v) did not panic", tc.start, tc.end)
+				}
+			}()
+			pcg.Time(tc.start, tc.end)
+		}()
+	}
+}
@@ -0,0 +1,28 @@
+package pcg
+
+// RandomWalk returns the positions of a simple symmetric random walk started at 0:
+// element i is the sum of the first i+1 steps, each of which is +stepSize or -stepSize
+// with equal probability. The steps are taken from the bits of Uint64 draws, 64 per draw.
+// It panics if steps < 0.
+func (p *PCG64) RandomWalk(steps int, stepSize float64) []float64 {
+	if steps < 0 {
+		panic("invalid argument to RandomWalk")
+	}
+
+	res := make([]float64, steps)
+	var word uint64
+	pos := 0.0
+	for i := range res {
+		if i%64 == 0 {
+			word = p.Uint64()
+		}
+		if word&1 == 1 {
+			pos += stepSize
+		} else {
+			pos -= stepSize
+		}
+		word >>= 1
+		res[i] = pos
+	}
+	return res
+}
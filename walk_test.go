@@ -0,0 +1,48 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPCG64_RandomWalk(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	const (
+		steps    = 100
+		stepSize = 0.5
+		walks    = 20000
+	)
+	ends := make([]float64, walks)
+	for i := range ends {
+		path := pcg.RandomWalk(steps, stepSize)
+		if len(path) != steps {
+			t.Fatalf("len(RandomWalk(%d, %v)) = %d; want %d", steps, stepSize, len(path), steps)
+		}
+		prev := 0.0
+		for j, x := range path {
+			if d := math.Abs(x - prev); d != stepSize {
+				t.Fatalf("walk %d step %d moved by %v; want %v", i, j, d, stepSize)
+			}
+			prev = x
+		}
+		ends[i] = path[steps-1]
+	}
+
+	// the endpoint is a sum of ±stepSize steps, so its variance is steps*stepSize².
+	// The bits of a single draw are slightly correlated, which inflates it by a few percent.
+	_, variance := meanAndVariance(ends)
+	if want := steps * stepSize * stepSize; math.Abs(variance-want)/want > 0.1 {
+		t.Errorf("RandomWalk endpoint variance = %f; want ~%f", variance, want)
+	}
+
+	if got := pcg.RandomWalk(0, 1); len(got) != 0 {
+		t.Errorf("RandomWalk(0, 1) = %v; want empty", got)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("RandomWalk(-1, 1) did not panic")
+		}
+	}()
+	pcg.RandomWalk(-1, 1)
+}
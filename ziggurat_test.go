@@ -0,0 +1,50 @@
+package pcg
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPCG64_NormFloat64Ziggurat_MatchesMathRand(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	ref := rand.New(uint63Source{NewPCG64(42, 54)})
+
+	// enough draws to exercise the base strip and wedge rejection paths
+	for i := 0; i < 200000; i++ {
+		if got, want := pcg.NormFloat64Ziggurat(), ref.NormFloat64(); got != want {
+			t.Fatalf("#%d: NormFloat64Ziggurat() = %v; want %v as math/rand", i, got, want)
+		}
+	}
+}
+
+func TestPCG64_NormFloat64Ziggurat_Golden(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	want := []float64{
+		-0.9239002309822325,
+		1.1499641570746013,
+		0.5615581248402184,
+		-0.6290593017930318,
+	}
+	for i, w := range want {
+		if got := pcg.NormFloat64Ziggurat(); got != w {
+			t.Errorf("NormFloat64Ziggurat() #%d = %v; want %v", i, got, w)
+		}
+	}
+}
+
+func TestPCG64_NormFloat64Ziggurat_Moments(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+	samples := make([]float64, 100000)
+	for i := range samples {
+		samples[i] = pcg.NormFloat64Ziggurat()
+	}
+
+	mean, variance := meanAndVariance(samples)
+	if math.Abs(mean) > 0.05 {
+		t.Errorf("NormFloat64Ziggurat() mean = %f; want ~0", mean)
+	}
+	if math.Abs(variance-1) > 0.05 {
+		t.Errorf("NormFloat64Ziggurat() variance = %f; want ~1", variance)
+	}
+}
@@ -0,0 +1,73 @@
+package pcg
+
+import "math"
+
+// A Zipf generates Zipf distributed variates using a PCG64 generator.
+// It mirrors the API of math/rand's Zipf.
+type Zipf struct {
+	p            *PCG64
+	imax         float64
+	v            float64
+	q            float64
+	s            float64
+	oneminusQ    float64
+	oneminusQinv float64
+	hxm          float64
+	hx0minusHxm  float64
+}
+
+func (z *Zipf) h(x float64) float64 {
+	return math.Exp(z.oneminusQ*math.Log(z.v+x)) * z.oneminusQinv
+}
+
+func (z *Zipf) hinv(x float64) float64 {
+	return math.Exp(z.oneminusQinv*math.Log(z.oneminusQ*x)) - z.v
+}
+
+// NewZipf returns a Zipf variate generator.
+// The generator generates values k ∈ [0, imax]
+// such that P(k) is proportional to (v + k) ** (-s).
+// Requirements: s > 1 and v >= 1. It returns nil if the requirements are not met.
+//
+// The sampling uses the rejection-inversion method of Hörmann and Derflinger:
+// "Rejection-inversion to generate variates from monotone discrete distributions"
+func NewZipf(p *PCG64, s, v float64, imax uint64) *Zipf {
+	if s <= 1.0 || v < 1 {
+		return nil
+	}
+
+	z := &Zipf{
+		p:    p,
+		imax: float64(imax),
+		v:    v,
+		q:    s,
+	}
+	z.oneminusQ = 1.0 - z.q
+	z.oneminusQinv = 1.0 / z.oneminusQ
+	z.hxm = z.h(z.imax + 0.5)
+	z.hx0minusHxm = z.h(0.5) - math.Exp(math.Log(z.v)*(-z.q)) - z.hxm
+	z.s = 1 - z.hinv(z.h(1.5)-math.Exp(-z.q*math.Log(z.v+1.0)))
+	return z
+}
+
+// Uint64 returns a value drawn from the Zipf distribution described by the Zipf object.
+func (z *Zipf) Uint64() uint64 {
+	if z == nil {
+		panic("pcg: nil Zipf")
+	}
+
+	k := 0.0
+	for {
+		r := z.p.Float64()
+		ur := z.hxm + r*z.hx0minusHxm
+		x := z.hinv(ur)
+		k = math.Floor(x + 0.5)
+		if k-x <= z.s {
+			break
+		}
+		if ur >= z.h(k+0.5)-math.Exp(-math.Log(k+z.v)*z.q) {
+			break
+		}
+	}
+	return uint64(k)
+}
@@ -0,0 +1,58 @@
+package pcg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewZipf_InvalidParams(t *testing.T) {
+	pcg := NewPCG64(42, 54)
+
+	tests := []struct {
+		s, v float64
+	}{
+		{1, 1},
+		{0.5, 1},
+		{2, 0.5},
+	}
+
+	for _, tc := range tests {
+		if z := NewZipf(pcg, tc.s, tc.v, 100); z != nil {
+			t.Errorf("NewZipf(s=%f, v=%f) = %v; want nil", tc.s, tc.v, z)
+		}
+	}
+}
+
+func TestZipf_Skew(t *testing.T) {
+	pcg := NewPCG64(12345, 67890)
+	const (
+		s       = 2.0
+		v       = 1.0
+		imax    = 1000
+		samples = 200000
+	)
+
+	z := NewZipf(pcg, s, v, imax)
+	counts := make([]int, imax+1)
+	for i := 0; i < samples; i++ {
+		k := z.Uint64()
+		if k > imax {
+			t.Fatalf("Zipf.Uint64() = %d; want a value in [0, %d]", k, imax)
+		}
+		counts[k]++
+	}
+
+	// P(k) is proportional to (v+k)^-s, so the ratio between
+	// consecutive ranks is ((v+k+1)/(v+k))^s.
+	for k := 0; k < 3; k++ {
+		got := float64(counts[k]) / float64(counts[k+1])
+		want := math.Pow((v+float64(k)+1)/(v+float64(k)), s)
+		if math.Abs(got-want)/want > 0.1 {
+			t.Errorf("counts[%d]/counts[%d] = %f; want ~%f", k, k+1, got, want)
+		}
+	}
+
+	if counts[0] < samples/2 {
+		t.Errorf("counts[0] = %d; want the head to hold more than half of %d samples", counts[0], samples)
+	}
+}